@@ -0,0 +1,4 @@
+// Package yamlmcpv1 contains the gRPC contract for external approvers and executors.
+package yamlmcpv1
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative yamlmcp.proto
@@ -0,0 +1,889 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: yamlmcp.proto
+
+package yamlmcpv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// EventType describes a streaming event stage.
+type EventType int32
+
+const (
+	EventType_EVENT_TYPE_UNSPECIFIED EventType = 0
+	// The request was accepted and is waiting for a decision or result.
+	EventType_EVENT_TYPE_PENDING EventType = 1
+	// Intermediate progress update.
+	EventType_EVENT_TYPE_PROGRESS EventType = 2
+	// Final decision or result; the stream ends after it.
+	EventType_EVENT_TYPE_FINAL EventType = 3
+)
+
+// Enum value maps for EventType.
+var (
+	EventType_name = map[int32]string{
+		0: "EVENT_TYPE_UNSPECIFIED",
+		1: "EVENT_TYPE_PENDING",
+		2: "EVENT_TYPE_PROGRESS",
+		3: "EVENT_TYPE_FINAL",
+	}
+	EventType_value = map[string]int32{
+		"EVENT_TYPE_UNSPECIFIED": 0,
+		"EVENT_TYPE_PENDING":     1,
+		"EVENT_TYPE_PROGRESS":    2,
+		"EVENT_TYPE_FINAL":       3,
+	}
+)
+
+func (x EventType) Enum() *EventType {
+	p := new(EventType)
+	*p = x
+	return p
+}
+
+func (x EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_yamlmcp_proto_enumTypes[0].Descriptor()
+}
+
+func (EventType) Type() protoreflect.EnumType {
+	return &file_yamlmcp_proto_enumTypes[0]
+}
+
+func (x EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventType.Descriptor instead.
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return file_yamlmcp_proto_rawDescGZIP(), []int{0}
+}
+
+// ApproverLink is a human-friendly code reference.
+type ApproverLink struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproverLink) Reset() {
+	*x = ApproverLink{}
+	mi := &file_yamlmcp_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproverLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproverLink) ProtoMessage() {}
+
+func (x *ApproverLink) ProtoReflect() protoreflect.Message {
+	mi := &file_yamlmcp_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproverLink.ProtoReflect.Descriptor instead.
+func (*ApproverLink) Descriptor() ([]byte, []int) {
+	return file_yamlmcp_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ApproverLink) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ApproverLink) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+// ApproverRequest mirrors the HTTP approver request payload.
+type ApproverRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CorrelationId string                 `protobuf:"bytes,1,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+	Tool          string                 `protobuf:"bytes,2,opt,name=tool,proto3" json:"tool,omitempty"`
+	Arguments     *structpb.Struct       `protobuf:"bytes,3,opt,name=arguments,proto3" json:"arguments,omitempty"`
+	// Short reason from the model (10-500 chars).
+	Justification string `protobuf:"bytes,4,opt,name=justification,proto3" json:"justification,omitempty"`
+	// Requested action summary (10-500 chars).
+	ApprovalRequest string `protobuf:"bytes,5,opt,name=approval_request,json=approvalRequest,proto3" json:"approval_request,omitempty"`
+	// Potential risks (10-500 chars).
+	RiskAssessment string          `protobuf:"bytes,6,opt,name=risk_assessment,json=riskAssessment,proto3" json:"risk_assessment,omitempty"`
+	LinksToCode    []*ApproverLink `protobuf:"bytes,7,rep,name=links_to_code,json=linksToCode,proto3" json:"links_to_code,omitempty"`
+	// Unified diff of the changes the tool will make.
+	Diff string `protobuf:"bytes,8,opt,name=diff,proto3" json:"diff,omitempty"`
+	// Message language (ru/en).
+	Lang string `protobuf:"bytes,9,opt,name=lang,proto3" json:"lang,omitempty"`
+	// Message formatting (markdown/html).
+	Markup        string `protobuf:"bytes,10,opt,name=markup,proto3" json:"markup,omitempty"`
+	TimeoutSec    int32  `protobuf:"varint,11,opt,name=timeout_sec,json=timeoutSec,proto3" json:"timeout_sec,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproverRequest) Reset() {
+	*x = ApproverRequest{}
+	mi := &file_yamlmcp_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproverRequest) ProtoMessage() {}
+
+func (x *ApproverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_yamlmcp_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproverRequest.ProtoReflect.Descriptor instead.
+func (*ApproverRequest) Descriptor() ([]byte, []int) {
+	return file_yamlmcp_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ApproverRequest) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *ApproverRequest) GetTool() string {
+	if x != nil {
+		return x.Tool
+	}
+	return ""
+}
+
+func (x *ApproverRequest) GetArguments() *structpb.Struct {
+	if x != nil {
+		return x.Arguments
+	}
+	return nil
+}
+
+func (x *ApproverRequest) GetJustification() string {
+	if x != nil {
+		return x.Justification
+	}
+	return ""
+}
+
+func (x *ApproverRequest) GetApprovalRequest() string {
+	if x != nil {
+		return x.ApprovalRequest
+	}
+	return ""
+}
+
+func (x *ApproverRequest) GetRiskAssessment() string {
+	if x != nil {
+		return x.RiskAssessment
+	}
+	return ""
+}
+
+func (x *ApproverRequest) GetLinksToCode() []*ApproverLink {
+	if x != nil {
+		return x.LinksToCode
+	}
+	return nil
+}
+
+func (x *ApproverRequest) GetDiff() string {
+	if x != nil {
+		return x.Diff
+	}
+	return ""
+}
+
+func (x *ApproverRequest) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *ApproverRequest) GetMarkup() string {
+	if x != nil {
+		return x.Markup
+	}
+	return ""
+}
+
+func (x *ApproverRequest) GetTimeoutSec() int32 {
+	if x != nil {
+		return x.TimeoutSec
+	}
+	return 0
+}
+
+// ApproverResponse mirrors the HTTP approver response payload.
+type ApproverResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One of approve, deny, error.
+	Decision      string `protobuf:"bytes,1,opt,name=decision,proto3" json:"decision,omitempty"`
+	Reason        string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	CorrelationId string `protobuf:"bytes,3,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+	RequestId     string `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproverResponse) Reset() {
+	*x = ApproverResponse{}
+	mi := &file_yamlmcp_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproverResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproverResponse) ProtoMessage() {}
+
+func (x *ApproverResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_yamlmcp_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproverResponse.ProtoReflect.Descriptor instead.
+func (*ApproverResponse) Descriptor() ([]byte, []int) {
+	return file_yamlmcp_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ApproverResponse) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+func (x *ApproverResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ApproverResponse) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *ApproverResponse) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+// ApproverEvent is a streaming approval update.
+type ApproverEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  EventType              `protobuf:"varint,1,opt,name=type,proto3,enum=yamlmcp.v1.EventType" json:"type,omitempty"`
+	// Optional human-readable progress message.
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// Optional progress percentage (0-100).
+	Percent float64 `protobuf:"fixed64,3,opt,name=percent,proto3" json:"percent,omitempty"`
+	// Final decision; required for EVENT_TYPE_FINAL.
+	Response      *ApproverResponse `protobuf:"bytes,4,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproverEvent) Reset() {
+	*x = ApproverEvent{}
+	mi := &file_yamlmcp_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproverEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproverEvent) ProtoMessage() {}
+
+func (x *ApproverEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_yamlmcp_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproverEvent.ProtoReflect.Descriptor instead.
+func (*ApproverEvent) Descriptor() ([]byte, []int) {
+	return file_yamlmcp_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ApproverEvent) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *ApproverEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ApproverEvent) GetPercent() float64 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *ApproverEvent) GetResponse() *ApproverResponse {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// ExecutorTool describes tool metadata for external executors.
+type ExecutorTool struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	InputSchema   *structpb.Struct       `protobuf:"bytes,4,opt,name=input_schema,json=inputSchema,proto3" json:"input_schema,omitempty"`
+	OutputSchema  *structpb.Struct       `protobuf:"bytes,5,opt,name=output_schema,json=outputSchema,proto3" json:"output_schema,omitempty"`
+	Metadata      *structpb.Struct       `protobuf:"bytes,6,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Tags          []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecutorTool) Reset() {
+	*x = ExecutorTool{}
+	mi := &file_yamlmcp_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecutorTool) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecutorTool) ProtoMessage() {}
+
+func (x *ExecutorTool) ProtoReflect() protoreflect.Message {
+	mi := &file_yamlmcp_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecutorTool.ProtoReflect.Descriptor instead.
+func (*ExecutorTool) Descriptor() ([]byte, []int) {
+	return file_yamlmcp_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ExecutorTool) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExecutorTool) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ExecutorTool) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ExecutorTool) GetInputSchema() *structpb.Struct {
+	if x != nil {
+		return x.InputSchema
+	}
+	return nil
+}
+
+func (x *ExecutorTool) GetOutputSchema() *structpb.Struct {
+	if x != nil {
+		return x.OutputSchema
+	}
+	return nil
+}
+
+func (x *ExecutorTool) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ExecutorTool) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// ExecutorRequest mirrors the HTTP executor request payload.
+type ExecutorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CorrelationId string                 `protobuf:"bytes,1,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+	Tool          *ExecutorTool          `protobuf:"bytes,2,opt,name=tool,proto3" json:"tool,omitempty"`
+	Arguments     *structpb.Struct       `protobuf:"bytes,3,opt,name=arguments,proto3" json:"arguments,omitempty"`
+	// Declarative executor settings from YAML.
+	Spec          *structpb.Struct `protobuf:"bytes,4,opt,name=spec,proto3" json:"spec,omitempty"`
+	Lang          string           `protobuf:"bytes,5,opt,name=lang,proto3" json:"lang,omitempty"`
+	Markup        string           `protobuf:"bytes,6,opt,name=markup,proto3" json:"markup,omitempty"`
+	TimeoutSec    int32            `protobuf:"varint,7,opt,name=timeout_sec,json=timeoutSec,proto3" json:"timeout_sec,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecutorRequest) Reset() {
+	*x = ExecutorRequest{}
+	mi := &file_yamlmcp_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecutorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecutorRequest) ProtoMessage() {}
+
+func (x *ExecutorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_yamlmcp_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecutorRequest.ProtoReflect.Descriptor instead.
+func (*ExecutorRequest) Descriptor() ([]byte, []int) {
+	return file_yamlmcp_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ExecutorRequest) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *ExecutorRequest) GetTool() *ExecutorTool {
+	if x != nil {
+		return x.Tool
+	}
+	return nil
+}
+
+func (x *ExecutorRequest) GetArguments() *structpb.Struct {
+	if x != nil {
+		return x.Arguments
+	}
+	return nil
+}
+
+func (x *ExecutorRequest) GetSpec() *structpb.Struct {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+func (x *ExecutorRequest) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *ExecutorRequest) GetMarkup() string {
+	if x != nil {
+		return x.Markup
+	}
+	return ""
+}
+
+func (x *ExecutorRequest) GetTimeoutSec() int32 {
+	if x != nil {
+		return x.TimeoutSec
+	}
+	return 0
+}
+
+// ExecutorResponse mirrors the HTTP executor response payload.
+type ExecutorResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One of success, error.
+	Status        string          `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Result        *structpb.Value `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+	CorrelationId string          `protobuf:"bytes,3,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+	RequestId     string          `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecutorResponse) Reset() {
+	*x = ExecutorResponse{}
+	mi := &file_yamlmcp_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecutorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecutorResponse) ProtoMessage() {}
+
+func (x *ExecutorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_yamlmcp_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecutorResponse.ProtoReflect.Descriptor instead.
+func (*ExecutorResponse) Descriptor() ([]byte, []int) {
+	return file_yamlmcp_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ExecutorResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ExecutorResponse) GetResult() *structpb.Value {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *ExecutorResponse) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *ExecutorResponse) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+// ExecutorEvent is a streaming execution update.
+type ExecutorEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  EventType              `protobuf:"varint,1,opt,name=type,proto3,enum=yamlmcp.v1.EventType" json:"type,omitempty"`
+	// Optional human-readable progress message.
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// Optional progress percentage (0-100).
+	Percent float64 `protobuf:"fixed64,3,opt,name=percent,proto3" json:"percent,omitempty"`
+	// Final result; required for EVENT_TYPE_FINAL.
+	Response      *ExecutorResponse `protobuf:"bytes,4,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecutorEvent) Reset() {
+	*x = ExecutorEvent{}
+	mi := &file_yamlmcp_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecutorEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecutorEvent) ProtoMessage() {}
+
+func (x *ExecutorEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_yamlmcp_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecutorEvent.ProtoReflect.Descriptor instead.
+func (*ExecutorEvent) Descriptor() ([]byte, []int) {
+	return file_yamlmcp_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ExecutorEvent) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *ExecutorEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ExecutorEvent) GetPercent() float64 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *ExecutorEvent) GetResponse() *ExecutorResponse {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+var File_yamlmcp_proto protoreflect.FileDescriptor
+
+const file_yamlmcp_proto_rawDesc = "" +
+	"\n" +
+	"\ryamlmcp.proto\x12\n" +
+	"yamlmcp.v1\x1a\x1cgoogle/protobuf/struct.proto\"4\n" +
+	"\fApproverLink\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\"\x9c\x03\n" +
+	"\x0fApproverRequest\x12%\n" +
+	"\x0ecorrelation_id\x18\x01 \x01(\tR\rcorrelationId\x12\x12\n" +
+	"\x04tool\x18\x02 \x01(\tR\x04tool\x125\n" +
+	"\targuments\x18\x03 \x01(\v2\x17.google.protobuf.StructR\targuments\x12$\n" +
+	"\rjustification\x18\x04 \x01(\tR\rjustification\x12)\n" +
+	"\x10approval_request\x18\x05 \x01(\tR\x0fapprovalRequest\x12'\n" +
+	"\x0frisk_assessment\x18\x06 \x01(\tR\x0eriskAssessment\x12<\n" +
+	"\rlinks_to_code\x18\a \x03(\v2\x18.yamlmcp.v1.ApproverLinkR\vlinksToCode\x12\x12\n" +
+	"\x04diff\x18\b \x01(\tR\x04diff\x12\x12\n" +
+	"\x04lang\x18\t \x01(\tR\x04lang\x12\x16\n" +
+	"\x06markup\x18\n" +
+	" \x01(\tR\x06markup\x12\x1f\n" +
+	"\vtimeout_sec\x18\v \x01(\x05R\n" +
+	"timeoutSec\"\x8c\x01\n" +
+	"\x10ApproverResponse\x12\x1a\n" +
+	"\bdecision\x18\x01 \x01(\tR\bdecision\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12%\n" +
+	"\x0ecorrelation_id\x18\x03 \x01(\tR\rcorrelationId\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x04 \x01(\tR\trequestId\"\xa8\x01\n" +
+	"\rApproverEvent\x12)\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x15.yamlmcp.v1.EventTypeR\x04type\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x18\n" +
+	"\apercent\x18\x03 \x01(\x01R\apercent\x128\n" +
+	"\bresponse\x18\x04 \x01(\v2\x1c.yamlmcp.v1.ApproverResponseR\bresponse\"\x9d\x02\n" +
+	"\fExecutorTool\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12:\n" +
+	"\finput_schema\x18\x04 \x01(\v2\x17.google.protobuf.StructR\vinputSchema\x12<\n" +
+	"\routput_schema\x18\x05 \x01(\v2\x17.google.protobuf.StructR\foutputSchema\x123\n" +
+	"\bmetadata\x18\x06 \x01(\v2\x17.google.protobuf.StructR\bmetadata\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\"\x97\x02\n" +
+	"\x0fExecutorRequest\x12%\n" +
+	"\x0ecorrelation_id\x18\x01 \x01(\tR\rcorrelationId\x12,\n" +
+	"\x04tool\x18\x02 \x01(\v2\x18.yamlmcp.v1.ExecutorToolR\x04tool\x125\n" +
+	"\targuments\x18\x03 \x01(\v2\x17.google.protobuf.StructR\targuments\x12+\n" +
+	"\x04spec\x18\x04 \x01(\v2\x17.google.protobuf.StructR\x04spec\x12\x12\n" +
+	"\x04lang\x18\x05 \x01(\tR\x04lang\x12\x16\n" +
+	"\x06markup\x18\x06 \x01(\tR\x06markup\x12\x1f\n" +
+	"\vtimeout_sec\x18\a \x01(\x05R\n" +
+	"timeoutSec\"\xa0\x01\n" +
+	"\x10ExecutorResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12.\n" +
+	"\x06result\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\x06result\x12%\n" +
+	"\x0ecorrelation_id\x18\x03 \x01(\tR\rcorrelationId\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x04 \x01(\tR\trequestId\"\xa8\x01\n" +
+	"\rExecutorEvent\x12)\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x15.yamlmcp.v1.EventTypeR\x04type\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x18\n" +
+	"\apercent\x18\x03 \x01(\x01R\apercent\x128\n" +
+	"\bresponse\x18\x04 \x01(\v2\x1c.yamlmcp.v1.ExecutorResponseR\bresponse*n\n" +
+	"\tEventType\x12\x1a\n" +
+	"\x16EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12EVENT_TYPE_PENDING\x10\x01\x12\x17\n" +
+	"\x13EVENT_TYPE_PROGRESS\x10\x02\x12\x14\n" +
+	"\x10EVENT_TYPE_FINAL\x10\x032\xa2\x01\n" +
+	"\x0fApproverService\x12D\n" +
+	"\aApprove\x12\x1b.yamlmcp.v1.ApproverRequest\x1a\x1c.yamlmcp.v1.ApproverResponse\x12I\n" +
+	"\rApproveStream\x12\x1b.yamlmcp.v1.ApproverRequest\x1a\x19.yamlmcp.v1.ApproverEvent0\x012\xa2\x01\n" +
+	"\x0fExecutorService\x12D\n" +
+	"\aExecute\x12\x1b.yamlmcp.v1.ExecutorRequest\x1a\x1c.yamlmcp.v1.ExecutorResponse\x12I\n" +
+	"\rExecuteStream\x12\x1b.yamlmcp.v1.ExecutorRequest\x1a\x19.yamlmcp.v1.ExecutorEvent0\x01B?Z=github.com/codex-k8s/yaml-mcp-server/api/yamlmcp/v1;yamlmcpv1b\x06proto3"
+
+var (
+	file_yamlmcp_proto_rawDescOnce sync.Once
+	file_yamlmcp_proto_rawDescData []byte
+)
+
+func file_yamlmcp_proto_rawDescGZIP() []byte {
+	file_yamlmcp_proto_rawDescOnce.Do(func() {
+		file_yamlmcp_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_yamlmcp_proto_rawDesc), len(file_yamlmcp_proto_rawDesc)))
+	})
+	return file_yamlmcp_proto_rawDescData
+}
+
+var file_yamlmcp_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_yamlmcp_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_yamlmcp_proto_goTypes = []any{
+	(EventType)(0),           // 0: yamlmcp.v1.EventType
+	(*ApproverLink)(nil),     // 1: yamlmcp.v1.ApproverLink
+	(*ApproverRequest)(nil),  // 2: yamlmcp.v1.ApproverRequest
+	(*ApproverResponse)(nil), // 3: yamlmcp.v1.ApproverResponse
+	(*ApproverEvent)(nil),    // 4: yamlmcp.v1.ApproverEvent
+	(*ExecutorTool)(nil),     // 5: yamlmcp.v1.ExecutorTool
+	(*ExecutorRequest)(nil),  // 6: yamlmcp.v1.ExecutorRequest
+	(*ExecutorResponse)(nil), // 7: yamlmcp.v1.ExecutorResponse
+	(*ExecutorEvent)(nil),    // 8: yamlmcp.v1.ExecutorEvent
+	(*structpb.Struct)(nil),  // 9: google.protobuf.Struct
+	(*structpb.Value)(nil),   // 10: google.protobuf.Value
+}
+var file_yamlmcp_proto_depIdxs = []int32{
+	9,  // 0: yamlmcp.v1.ApproverRequest.arguments:type_name -> google.protobuf.Struct
+	1,  // 1: yamlmcp.v1.ApproverRequest.links_to_code:type_name -> yamlmcp.v1.ApproverLink
+	0,  // 2: yamlmcp.v1.ApproverEvent.type:type_name -> yamlmcp.v1.EventType
+	3,  // 3: yamlmcp.v1.ApproverEvent.response:type_name -> yamlmcp.v1.ApproverResponse
+	9,  // 4: yamlmcp.v1.ExecutorTool.input_schema:type_name -> google.protobuf.Struct
+	9,  // 5: yamlmcp.v1.ExecutorTool.output_schema:type_name -> google.protobuf.Struct
+	9,  // 6: yamlmcp.v1.ExecutorTool.metadata:type_name -> google.protobuf.Struct
+	5,  // 7: yamlmcp.v1.ExecutorRequest.tool:type_name -> yamlmcp.v1.ExecutorTool
+	9,  // 8: yamlmcp.v1.ExecutorRequest.arguments:type_name -> google.protobuf.Struct
+	9,  // 9: yamlmcp.v1.ExecutorRequest.spec:type_name -> google.protobuf.Struct
+	10, // 10: yamlmcp.v1.ExecutorResponse.result:type_name -> google.protobuf.Value
+	0,  // 11: yamlmcp.v1.ExecutorEvent.type:type_name -> yamlmcp.v1.EventType
+	7,  // 12: yamlmcp.v1.ExecutorEvent.response:type_name -> yamlmcp.v1.ExecutorResponse
+	2,  // 13: yamlmcp.v1.ApproverService.Approve:input_type -> yamlmcp.v1.ApproverRequest
+	2,  // 14: yamlmcp.v1.ApproverService.ApproveStream:input_type -> yamlmcp.v1.ApproverRequest
+	6,  // 15: yamlmcp.v1.ExecutorService.Execute:input_type -> yamlmcp.v1.ExecutorRequest
+	6,  // 16: yamlmcp.v1.ExecutorService.ExecuteStream:input_type -> yamlmcp.v1.ExecutorRequest
+	3,  // 17: yamlmcp.v1.ApproverService.Approve:output_type -> yamlmcp.v1.ApproverResponse
+	4,  // 18: yamlmcp.v1.ApproverService.ApproveStream:output_type -> yamlmcp.v1.ApproverEvent
+	7,  // 19: yamlmcp.v1.ExecutorService.Execute:output_type -> yamlmcp.v1.ExecutorResponse
+	8,  // 20: yamlmcp.v1.ExecutorService.ExecuteStream:output_type -> yamlmcp.v1.ExecutorEvent
+	17, // [17:21] is the sub-list for method output_type
+	13, // [13:17] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_yamlmcp_proto_init() }
+func file_yamlmcp_proto_init() {
+	if File_yamlmcp_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_yamlmcp_proto_rawDesc), len(file_yamlmcp_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_yamlmcp_proto_goTypes,
+		DependencyIndexes: file_yamlmcp_proto_depIdxs,
+		EnumInfos:         file_yamlmcp_proto_enumTypes,
+		MessageInfos:      file_yamlmcp_proto_msgTypes,
+	}.Build()
+	File_yamlmcp_proto = out.File
+	file_yamlmcp_proto_goTypes = nil
+	file_yamlmcp_proto_depIdxs = nil
+}
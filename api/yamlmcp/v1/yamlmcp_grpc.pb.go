@@ -0,0 +1,327 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: yamlmcp.proto
+
+package yamlmcpv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ApproverService_Approve_FullMethodName       = "/yamlmcp.v1.ApproverService/Approve"
+	ApproverService_ApproveStream_FullMethodName = "/yamlmcp.v1.ApproverService/ApproveStream"
+)
+
+// ApproverServiceClient is the client API for ApproverService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ApproverService is implemented by external approvers.
+type ApproverServiceClient interface {
+	// Approve returns a final decision for the request.
+	Approve(ctx context.Context, in *ApproverRequest, opts ...grpc.CallOption) (*ApproverResponse, error)
+	// ApproveStream emits pending/progress events followed by a single final event.
+	// It replaces the webhook callback flow of async HTTP approvers.
+	ApproveStream(ctx context.Context, in *ApproverRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ApproverEvent], error)
+}
+
+type approverServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewApproverServiceClient(cc grpc.ClientConnInterface) ApproverServiceClient {
+	return &approverServiceClient{cc}
+}
+
+func (c *approverServiceClient) Approve(ctx context.Context, in *ApproverRequest, opts ...grpc.CallOption) (*ApproverResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApproverResponse)
+	err := c.cc.Invoke(ctx, ApproverService_Approve_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *approverServiceClient) ApproveStream(ctx context.Context, in *ApproverRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ApproverEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ApproverService_ServiceDesc.Streams[0], ApproverService_ApproveStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ApproverRequest, ApproverEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ApproverService_ApproveStreamClient = grpc.ServerStreamingClient[ApproverEvent]
+
+// ApproverServiceServer is the server API for ApproverService service.
+// All implementations must embed UnimplementedApproverServiceServer
+// for forward compatibility.
+//
+// ApproverService is implemented by external approvers.
+type ApproverServiceServer interface {
+	// Approve returns a final decision for the request.
+	Approve(context.Context, *ApproverRequest) (*ApproverResponse, error)
+	// ApproveStream emits pending/progress events followed by a single final event.
+	// It replaces the webhook callback flow of async HTTP approvers.
+	ApproveStream(*ApproverRequest, grpc.ServerStreamingServer[ApproverEvent]) error
+	mustEmbedUnimplementedApproverServiceServer()
+}
+
+// UnimplementedApproverServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedApproverServiceServer struct{}
+
+func (UnimplementedApproverServiceServer) Approve(context.Context, *ApproverRequest) (*ApproverResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Approve not implemented")
+}
+func (UnimplementedApproverServiceServer) ApproveStream(*ApproverRequest, grpc.ServerStreamingServer[ApproverEvent]) error {
+	return status.Error(codes.Unimplemented, "method ApproveStream not implemented")
+}
+func (UnimplementedApproverServiceServer) mustEmbedUnimplementedApproverServiceServer() {}
+func (UnimplementedApproverServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeApproverServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ApproverServiceServer will
+// result in compilation errors.
+type UnsafeApproverServiceServer interface {
+	mustEmbedUnimplementedApproverServiceServer()
+}
+
+func RegisterApproverServiceServer(s grpc.ServiceRegistrar, srv ApproverServiceServer) {
+	// If the following call panics, it indicates UnimplementedApproverServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ApproverService_ServiceDesc, srv)
+}
+
+func _ApproverService_Approve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApproverServiceServer).Approve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ApproverService_Approve_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApproverServiceServer).Approve(ctx, req.(*ApproverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApproverService_ApproveStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ApproverRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ApproverServiceServer).ApproveStream(m, &grpc.GenericServerStream[ApproverRequest, ApproverEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ApproverService_ApproveStreamServer = grpc.ServerStreamingServer[ApproverEvent]
+
+// ApproverService_ServiceDesc is the grpc.ServiceDesc for ApproverService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ApproverService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "yamlmcp.v1.ApproverService",
+	HandlerType: (*ApproverServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Approve",
+			Handler:    _ApproverService_Approve_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ApproveStream",
+			Handler:       _ApproverService_ApproveStream_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "yamlmcp.proto",
+}
+
+const (
+	ExecutorService_Execute_FullMethodName       = "/yamlmcp.v1.ExecutorService/Execute"
+	ExecutorService_ExecuteStream_FullMethodName = "/yamlmcp.v1.ExecutorService/ExecuteStream"
+)
+
+// ExecutorServiceClient is the client API for ExecutorService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ExecutorService is implemented by external executors.
+type ExecutorServiceClient interface {
+	// Execute runs the tool and returns its result.
+	Execute(ctx context.Context, in *ExecutorRequest, opts ...grpc.CallOption) (*ExecutorResponse, error)
+	// ExecuteStream emits pending/progress events followed by a single final event.
+	// It replaces the webhook callback flow of async HTTP executors.
+	ExecuteStream(ctx context.Context, in *ExecutorRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExecutorEvent], error)
+}
+
+type executorServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExecutorServiceClient(cc grpc.ClientConnInterface) ExecutorServiceClient {
+	return &executorServiceClient{cc}
+}
+
+func (c *executorServiceClient) Execute(ctx context.Context, in *ExecutorRequest, opts ...grpc.CallOption) (*ExecutorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExecutorResponse)
+	err := c.cc.Invoke(ctx, ExecutorService_Execute_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executorServiceClient) ExecuteStream(ctx context.Context, in *ExecutorRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExecutorEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ExecutorService_ServiceDesc.Streams[0], ExecutorService_ExecuteStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExecutorRequest, ExecutorEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ExecutorService_ExecuteStreamClient = grpc.ServerStreamingClient[ExecutorEvent]
+
+// ExecutorServiceServer is the server API for ExecutorService service.
+// All implementations must embed UnimplementedExecutorServiceServer
+// for forward compatibility.
+//
+// ExecutorService is implemented by external executors.
+type ExecutorServiceServer interface {
+	// Execute runs the tool and returns its result.
+	Execute(context.Context, *ExecutorRequest) (*ExecutorResponse, error)
+	// ExecuteStream emits pending/progress events followed by a single final event.
+	// It replaces the webhook callback flow of async HTTP executors.
+	ExecuteStream(*ExecutorRequest, grpc.ServerStreamingServer[ExecutorEvent]) error
+	mustEmbedUnimplementedExecutorServiceServer()
+}
+
+// UnimplementedExecutorServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedExecutorServiceServer struct{}
+
+func (UnimplementedExecutorServiceServer) Execute(context.Context, *ExecutorRequest) (*ExecutorResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Execute not implemented")
+}
+func (UnimplementedExecutorServiceServer) ExecuteStream(*ExecutorRequest, grpc.ServerStreamingServer[ExecutorEvent]) error {
+	return status.Error(codes.Unimplemented, "method ExecuteStream not implemented")
+}
+func (UnimplementedExecutorServiceServer) mustEmbedUnimplementedExecutorServiceServer() {}
+func (UnimplementedExecutorServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeExecutorServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExecutorServiceServer will
+// result in compilation errors.
+type UnsafeExecutorServiceServer interface {
+	mustEmbedUnimplementedExecutorServiceServer()
+}
+
+func RegisterExecutorServiceServer(s grpc.ServiceRegistrar, srv ExecutorServiceServer) {
+	// If the following call panics, it indicates UnimplementedExecutorServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ExecutorService_ServiceDesc, srv)
+}
+
+func _ExecutorService_Execute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecutorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorServiceServer).Execute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExecutorService_Execute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorServiceServer).Execute(ctx, req.(*ExecutorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecutorService_ExecuteStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExecutorRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExecutorServiceServer).ExecuteStream(m, &grpc.GenericServerStream[ExecutorRequest, ExecutorEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ExecutorService_ExecuteStreamServer = grpc.ServerStreamingServer[ExecutorEvent]
+
+// ExecutorService_ServiceDesc is the grpc.ServiceDesc for ExecutorService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExecutorService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "yamlmcp.v1.ExecutorService",
+	HandlerType: (*ExecutorServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Execute",
+			Handler:    _ExecutorService_Execute_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExecuteStream",
+			Handler:       _ExecutorService_ExecuteStream_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "yamlmcp.proto",
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+
+	"github.com/codex-k8s/yaml-mcp-server/configs"
+	"github.com/codex-k8s/yaml-mcp-server/internal/agecrypt"
+	"github.com/codex-k8s/yaml-mcp-server/internal/approver/policy"
+	"github.com/codex-k8s/yaml-mcp-server/internal/config"
+	"github.com/codex-k8s/yaml-mcp-server/internal/constants"
+	"github.com/codex-k8s/yaml-mcp-server/internal/dsl"
+	"github.com/codex-k8s/yaml-mcp-server/internal/render"
+	"github.com/codex-k8s/yaml-mcp-server/internal/runtime"
+	"github.com/codex-k8s/yaml-mcp-server/internal/runtime/approver"
+)
+
+// loadDSL renders and parses the embedded config (when set) or the config file.
+func loadDSL(embeddedConfig, configPath string) (*dsl.Config, error) {
+	var (
+		rendered []byte
+		err      error
+	)
+	if embeddedConfig != "" {
+		raw, loadErr := configs.Load(embeddedConfig)
+		if loadErr != nil {
+			return nil, fmt.Errorf("load embedded config: %w", loadErr)
+		}
+		rendered, err = render.RenderBytes(embeddedConfig, raw)
+	} else {
+		rendered, err = render.RenderFile(configPath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("render config: %w", err)
+	}
+	dslCfg, err := dsl.Load(rendered)
+	if err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+	return dslCfg, nil
+}
+
+// runValidate renders and validates the config without starting the server.
+func runValidate(embeddedConfig string) int {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "config error: %v\n", err)
+		return 1
+	}
+	dslCfg, err := loadDSL(embeddedConfig, cfg.ConfigPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid config: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(os.Stdout, "config is valid: %d tools, %d resources\n", len(dslCfg.Tools), len(dslCfg.Resources))
+	return 0
+}
+
+// runEncrypt reads a value from stdin and prints it as an age-encrypted config value.
+func runEncrypt(args []string) int {
+	fs := flag.NewFlagSet("encrypt", flag.ContinueOnError)
+	recipientsFlag := fs.String("recipient", "", "Comma-separated age public keys (default: derived from "+agecrypt.KeyFileEnv+")")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	var (
+		recipients []age.Recipient
+		err        error
+	)
+	if strings.TrimSpace(*recipientsFlag) != "" {
+		recipients, err = agecrypt.ParseRecipients(*recipientsFlag)
+	} else {
+		var identities []age.Identity
+		identities, err = agecrypt.LoadIdentities()
+		recipients = agecrypt.Recipients(identities)
+	}
+	if err == nil && len(recipients) == 0 {
+		err = fmt.Errorf("no recipients")
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "encrypt: %v\n", err)
+		return 1
+	}
+
+	raw, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "encrypt: read stdin: %v\n", err)
+		return 1
+	}
+	value, err := agecrypt.Encrypt(strings.TrimRight(string(raw), "\r\n"), recipients...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "encrypt: %v\n", err)
+		return 1
+	}
+	fmt.Fprintln(os.Stdout, value)
+	return 0
+}
+
+// runPolicy dispatches policy subcommands.
+func runPolicy(embeddedConfig string, args []string) int {
+	if len(args) == 0 || args[0] != "test" {
+		fmt.Fprintln(os.Stderr, "usage: yaml-mcp-server policy test -tool <name> [-caller <name>] [-now <RFC3339>] [-expect allow|deny] <args.json>...")
+		return 2
+	}
+	return runPolicyTest(embeddedConfig, args[1:])
+}
+
+// runPolicyTest evaluates the policy approvers of a tool against sample argument files.
+func runPolicyTest(embeddedConfig string, args []string) int {
+	fs := flag.NewFlagSet("policy test", flag.ContinueOnError)
+	toolFlag := fs.String("tool", "", "Tool name")
+	callerFlag := fs.String("caller", "", "MCP client name visible as caller.name")
+	nowFlag := fs.String("now", "", "Evaluation time in RFC3339 (default: current time)")
+	expectFlag := fs.String("expect", "", "Expected effect (allow/deny); mismatches fail the command")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	expect := strings.ToLower(strings.TrimSpace(*expectFlag))
+	if strings.TrimSpace(*toolFlag) == "" || fs.NArg() == 0 ||
+		(expect != "" && expect != constants.PolicyAllow && expect != constants.PolicyDeny) {
+		fs.Usage()
+		return 2
+	}
+	now := time.Now()
+	if strings.TrimSpace(*nowFlag) != "" {
+		parsed, err := time.Parse(time.RFC3339, strings.TrimSpace(*nowFlag))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "policy test: invalid -now: %v\n", err)
+			return 2
+		}
+		now = parsed
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "config error: %v\n", err)
+		return 1
+	}
+	dslCfg, err := loadDSL(embeddedConfig, cfg.ConfigPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid config: %v\n", err)
+		return 1
+	}
+	var tool *dsl.ToolConfig
+	for i := range dslCfg.Tools {
+		if dslCfg.Tools[i].Name == *toolFlag {
+			tool = &dslCfg.Tools[i]
+		}
+	}
+	if tool == nil {
+		fmt.Fprintf(os.Stderr, "policy test: unknown tool: %s\n", *toolFlag)
+		return 1
+	}
+	policies, err := collectPolicies(*tool, "approvers", tool.Approvers)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "policy test: %v\n", err)
+		return 1
+	}
+	if len(policies) == 0 {
+		fmt.Fprintf(os.Stderr, "policy test: tool %s has no policy approvers\n", tool.Name)
+		return 1
+	}
+
+	code := 0
+	for _, path := range fs.Args() {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "policy test: %v\n", err)
+			return 1
+		}
+		var toolArgs map[string]any
+		if err := json.Unmarshal(raw, &toolArgs); err != nil {
+			fmt.Fprintf(os.Stderr, "policy test: %s: %v\n", path, err)
+			return 1
+		}
+		req := approver.Request{ToolName: tool.Name, Arguments: toolArgs, CorrelationID: "policy-test", Caller: approver.Caller{Name: *callerFlag}}
+		for _, item := range policies {
+			result := item.approver.Evaluate(req, now)
+			effect := constants.PolicyDeny
+			if result.Allowed {
+				effect = constants.PolicyAllow
+			}
+			rule := result.Rule
+			if rule == "" {
+				rule = "default"
+			}
+			status := ""
+			if expect != "" && effect != expect {
+				status = " (expected " + expect + ")"
+				code = 1
+			}
+			fmt.Fprintf(os.Stdout, "%s: %s: %s by %s%s: %s\n", path, item.path, effect, rule, status, result.Reason)
+		}
+	}
+	return code
+}
+
+type namedPolicy struct {
+	path     string
+	approver *policy.Approver
+}
+
+// collectPolicies finds policy approvers, including nested ones (see collectPolicy).
+func collectPolicies(tool dsl.ToolConfig, prefix string, configs []dsl.ApproverConfig) ([]namedPolicy, error) {
+	var out []namedPolicy
+	for i, cfg := range configs {
+		nested, err := collectPolicy(tool, fmt.Sprintf("%s[%d]", prefix, i), cfg)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, nested...)
+	}
+	return out, nil
+}
+
+// collectPolicy returns the policy approvers at path, including group children, escalation entries and fallbacks.
+func collectPolicy(tool dsl.ToolConfig, path string, cfg dsl.ApproverConfig) ([]namedPolicy, error) {
+	var out []namedPolicy
+	if strings.EqualFold(strings.TrimSpace(cfg.Type), constants.ApproverPolicy) {
+		item, err := runtime.NewPolicyApprover(tool, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		out = append(out, namedPolicy{path: path, approver: item})
+	}
+	children, err := collectPolicies(tool, path+".approvers", cfg.Approvers)
+	if err != nil {
+		return nil, err
+	}
+	out = append(out, children...)
+	escalation, err := collectPolicies(tool, path+".escalation", cfg.Escalation)
+	if err != nil {
+		return nil, err
+	}
+	out = append(out, escalation...)
+	if cfg.Fallback != nil {
+		fallback, err := collectPolicy(tool, path+".fallback", *cfg.Fallback)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, fallback...)
+	}
+	return out, nil
+}
@@ -15,25 +15,38 @@ import (
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
-	"github.com/codex-k8s/yaml-mcp-server/configs"
 	"github.com/codex-k8s/yaml-mcp-server/internal/app"
 	approverhttp "github.com/codex-k8s/yaml-mcp-server/internal/approver/http"
 	"github.com/codex-k8s/yaml-mcp-server/internal/audit"
+	"github.com/codex-k8s/yaml-mcp-server/internal/breaker"
 	"github.com/codex-k8s/yaml-mcp-server/internal/config"
+	"github.com/codex-k8s/yaml-mcp-server/internal/constants"
 	"github.com/codex-k8s/yaml-mcp-server/internal/dsl"
+	"github.com/codex-k8s/yaml-mcp-server/internal/grpcclient"
 	"github.com/codex-k8s/yaml-mcp-server/internal/idempotency"
 	"github.com/codex-k8s/yaml-mcp-server/internal/log"
-	"github.com/codex-k8s/yaml-mcp-server/internal/render"
+	"github.com/codex-k8s/yaml-mcp-server/internal/pending"
+	"github.com/codex-k8s/yaml-mcp-server/internal/plugin"
 	"github.com/codex-k8s/yaml-mcp-server/internal/runtime"
 	runtimeexecutor "github.com/codex-k8s/yaml-mcp-server/internal/runtime/executor"
 	"github.com/codex-k8s/yaml-mcp-server/internal/startup"
 	"github.com/codex-k8s/yaml-mcp-server/internal/templates"
+	"github.com/codex-k8s/yaml-mcp-server/internal/timeutil"
 )
 
 func main() {
 	embeddedConfig := flag.String("embedded-config", "", "Use embedded config from configs/ (filename)")
 	flag.Parse()
 
+	switch flag.Arg(0) {
+	case "validate":
+		os.Exit(runValidate(*embeddedConfig))
+	case "encrypt":
+		os.Exit(runEncrypt(flag.Args()[1:]))
+	case "policy":
+		os.Exit(runPolicy(*embeddedConfig, flag.Args()[1:]))
+	}
+
 	cfg, err := config.Load()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "config error: %v\n", err)
@@ -42,25 +55,9 @@ func main() {
 
 	logger := log.New(cfg.LogLevel)
 
-	var rendered []byte
-	if embeddedConfig != nil && *embeddedConfig != "" {
-		raw, err := configs.Load(*embeddedConfig)
-		if err != nil {
-			logger.Error("load embedded config failed", "error", err)
-			os.Exit(1)
-		}
-		rendered, err = render.RenderBytes(*embeddedConfig, raw)
-	} else {
-		rendered, err = render.RenderFile(cfg.ConfigPath)
-	}
+	dslCfg, err := loadDSL(*embeddedConfig, cfg.ConfigPath)
 	if err != nil {
-		logger.Error("render config failed", "error", err)
-		os.Exit(1)
-	}
-
-	dslCfg, err := dsl.Load(rendered)
-	if err != nil {
-		logger.Error("parse config failed", "error", err)
+		logger.Error("load config failed", "error", err)
 		os.Exit(1)
 	}
 
@@ -89,22 +86,31 @@ func main() {
 		Lang:               cfg.Lang,
 		ApprovalWebhookURL: dslCfg.Server.ApprovalWebhookURL,
 		ExecutorWebhookURL: dslCfg.Server.ExecutorWebhookURL,
+		Plugins:            plugin.NewRegistry(logger),
+		GRPC:               grpcclient.NewPool(),
+		Breakers:           breaker.NewRegistry(logger),
 	}
-	if hasAsyncHTTPApprover(dslCfg) {
-		builder.HTTPApprovals = approverhttp.NewPendingStore()
+	shutdownHooks := []func(context.Context) error{builder.Plugins.Close, builder.GRPC.Close}
+	approvalsBackend, executionsBackend, closePending, err := openPendingBackends(dslCfg.Server.PendingStore)
+	if err != nil {
+		logger.Error("open pending store failed", "error", err)
+		runShutdownHooks(shutdownHooks, cfg.ShutdownTimeout, logger)
+		os.Exit(1)
 	}
-	if builder.HTTPApprovals == nil && strings.TrimSpace(dslCfg.Server.ApprovalWebhookURL) != "" {
-		builder.HTTPApprovals = approverhttp.NewPendingStore()
+	if closePending != nil {
+		shutdownHooks = append(shutdownHooks, closePending)
 	}
-	if hasAsyncHTTPExecutor(dslCfg) {
-		builder.HTTPExecutions = runtimeexecutor.NewPendingStore()
+	pendingTTL := timeutil.ParseDurationOrDefault(dslCfg.Server.PendingStore.TTL, pending.DefaultTTL)
+	if hasAsyncHTTPApprover(dslCfg) || strings.TrimSpace(dslCfg.Server.ApprovalWebhookURL) != "" {
+		builder.HTTPApprovals = approverhttp.NewPendingStore(approvalsBackend, pendingTTL)
 	}
-	if builder.HTTPExecutions == nil && strings.TrimSpace(dslCfg.Server.ExecutorWebhookURL) != "" {
-		builder.HTTPExecutions = runtimeexecutor.NewPendingStore()
+	if hasAsyncHTTPExecutor(dslCfg) || strings.TrimSpace(dslCfg.Server.ExecutorWebhookURL) != "" {
+		builder.HTTPExecutions = runtimeexecutor.NewPendingStore(executionsBackend, pendingTTL)
 	}
 	server, err := builder.Build(dslCfg)
 	if err != nil {
 		logger.Error("build server failed", "error", err)
+		runShutdownHooks(shutdownHooks, cfg.ShutdownTimeout, logger)
 		os.Exit(1)
 	}
 
@@ -121,19 +127,23 @@ func main() {
 
 	if err := startup.Run(baseCtx, dslCfg.Server.StartupHooks, logger); err != nil {
 		logger.Error("startup hooks failed", "error", err)
+		runShutdownHooks(shutdownHooks, cfg.ShutdownTimeout, logger)
 		os.Exit(1)
 	}
 
 	switch dslCfg.Server.Transport {
 	case "stdio":
-		if err := runStdio(baseCtx, server); err != nil {
+		err := runStdio(baseCtx, server)
+		runShutdownHooks(shutdownHooks, cfg.ShutdownTimeout, logger)
+		if err != nil {
 			logger.Error("runtime error", "error", err)
 			os.Exit(1)
 		}
 		return
 	default:
-		if err := runHTTP(baseCtx, cfg, dslCfg, server, builder.HTTPApprovals, builder.HTTPExecutions, logger); err != nil {
+		if err := runHTTP(baseCtx, cfg, dslCfg, server, builder.HTTPApprovals, builder.HTTPExecutions, builder.Breakers, shutdownHooks, logger); err != nil {
 			logger.Error("runtime error", "error", err)
+			runShutdownHooks(shutdownHooks, cfg.ShutdownTimeout, logger)
 			os.Exit(1)
 		}
 	}
@@ -150,6 +160,8 @@ func runHTTP(
 	server *mcp.Server,
 	approvals *approverhttp.PendingStore,
 	executions *runtimeexecutor.PendingStore,
+	breakers *breaker.Registry,
+	shutdownHooks []func(context.Context) error,
 	logger *slog.Logger,
 ) error {
 	handler := mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server {
@@ -191,10 +203,51 @@ func runHTTP(
 	if err != nil {
 		return err
 	}
+	for _, hook := range shutdownHooks {
+		application.OnShutdown(hook)
+	}
+	if breakers.Len() > 0 {
+		application.AddReadinessDetail("circuit_breakers", func() any { return breakers.Snapshot() })
+	}
 
 	return application.Run(ctx)
 }
 
+func runShutdownHooks(hooks []func(context.Context) error, timeout time.Duration, logger *slog.Logger) {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	for _, hook := range hooks {
+		if err := hook(ctx); err != nil {
+			logger.Error("shutdown hook failed", "error", err)
+		}
+	}
+}
+
+func openPendingBackends(cfg dsl.PendingStoreConfig) (pending.Backend, pending.Backend, func(context.Context) error, error) {
+	if !strings.EqualFold(strings.TrimSpace(cfg.Type), constants.PendingStoreBolt) {
+		return nil, nil, nil, nil
+	}
+	db, err := pending.OpenDB(cfg.Path)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	closeDB := func(context.Context) error { return db.Close() }
+	approvals, err := db.Bucket("approvals")
+	if err != nil {
+		_ = db.Close()
+		return nil, nil, nil, err
+	}
+	executions, err := db.Bucket("executions")
+	if err != nil {
+		_ = db.Close()
+		return nil, nil, nil, err
+	}
+	return approvals, executions, closeDB, nil
+}
+
 func hasAsyncHTTPApprover(cfg *dsl.Config) bool {
 	if cfg == nil {
 		return false
@@ -0,0 +1,106 @@
+package agecrypt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"filippo.io/age"
+)
+
+// Prefix marks an encrypted value: "age:" followed by base64 of the binary age payload.
+const Prefix = "age:"
+
+// KeyFileEnv points to the age identity file used to decrypt config values.
+const KeyFileEnv = "YAML_MCP_AGE_KEY_FILE"
+
+// LoadIdentities reads age identities from the file referenced by KeyFileEnv.
+func LoadIdentities() ([]age.Identity, error) {
+	path := strings.TrimSpace(os.Getenv(KeyFileEnv))
+	if path == "" {
+		return nil, fmt.Errorf("%s is not set", KeyFileEnv)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open age key file: %w", err)
+	}
+	defer file.Close()
+	identities, err := age.ParseIdentities(file)
+	if err != nil {
+		return nil, fmt.Errorf("parse age key file: %w", err)
+	}
+	return identities, nil
+}
+
+// Recipients returns the public keys of X25519 identities.
+func Recipients(identities []age.Identity) []age.Recipient {
+	out := make([]age.Recipient, 0, len(identities))
+	for _, identity := range identities {
+		if x, ok := identity.(*age.X25519Identity); ok {
+			out = append(out, x.Recipient())
+		}
+	}
+	return out
+}
+
+// ParseRecipients parses comma-separated age public keys.
+func ParseRecipients(raw string) ([]age.Recipient, error) {
+	var out []age.Recipient
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		recipient, err := age.ParseX25519Recipient(item)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, recipient)
+	}
+	if len(out) == 0 {
+		return nil, errors.New("no recipients")
+	}
+	return out, nil
+}
+
+// Encrypt returns the prefixed, base64-encoded ciphertext of plaintext.
+func Encrypt(plaintext string, recipients ...age.Recipient) (string, error) {
+	var buf bytes.Buffer
+	writer, err := age.Encrypt(&buf, recipients...)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.WriteString(writer, plaintext); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	return Prefix + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// Decrypt decodes a value produced by Encrypt.
+// Errors never include the ciphertext or plaintext.
+func Decrypt(value string, identities ...age.Identity) (string, error) {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, Prefix) {
+		return "", fmt.Errorf("encrypted value must start with %q", Prefix)
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, Prefix))
+	if err != nil {
+		return "", errors.New("encrypted value is not valid base64")
+	}
+	reader, err := age.Decrypt(bytes.NewReader(raw), identities...)
+	if err != nil {
+		return "", fmt.Errorf("decrypt value: %w", err)
+	}
+	plaintext, err := io.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("decrypt value: %w", err)
+	}
+	return string(plaintext), nil
+}
@@ -0,0 +1,2 @@
+// Package agecrypt encrypts and decrypts single config values with age keys.
+package agecrypt
@@ -23,6 +23,7 @@ type App struct {
 	health          *health.Handler
 	logger          *slog.Logger
 	shutdownTimeout time.Duration
+	closers         []func(context.Context) error
 }
 
 // New initializes the HTTP server with health endpoints.
@@ -72,6 +73,20 @@ func New(baseCtx context.Context, serverCfg dsl.ServerConfig, handler http.Handl
 	}, nil
 }
 
+// OnShutdown registers a hook executed after the HTTP server stops.
+// Hooks run in registration order and share the shutdown timeout.
+func (a *App) OnShutdown(fn func(context.Context) error) {
+	if fn == nil {
+		return
+	}
+	a.closers = append(a.closers, fn)
+}
+
+// AddReadinessDetail reports a named value in the /readyz response body.
+func (a *App) AddReadinessDetail(name string, fn func() any) {
+	a.health.AddDetail(name, fn)
+}
+
 // Run starts the HTTP server and blocks until shutdown.
 func (a *App) Run(ctx context.Context) error {
 	errCh := make(chan error, 1)
@@ -106,8 +121,17 @@ func (a *App) shutdown() error {
 	a.health.SetNotReady()
 	ctx, cancel := context.WithTimeout(a.baseCtx, a.shutdownTimeout)
 	defer cancel()
+	var errs []error
 	if err := a.server.Shutdown(ctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
-		return err
+		errs = append(errs, err)
+	}
+	for _, closer := range a.closers {
+		if err := closer(ctx); err != nil {
+			if a.logger != nil {
+				a.logger.Error("shutdown hook failed", "error", err)
+			}
+			errs = append(errs, err)
+		}
 	}
-	return nil
+	return errors.Join(errs...)
 }
@@ -0,0 +1,3 @@
+// Package elicitation provides an approver that asks the user in the connected MCP client
+// via elicitation/create.
+package elicitation
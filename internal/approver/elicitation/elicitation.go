@@ -0,0 +1,120 @@
+package elicitation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/runtime/approver"
+	"github.com/codex-k8s/yaml-mcp-server/internal/templates"
+)
+
+// Approver shows the approval summary in the MCP client and waits for accept or decline.
+// Wrap it in approver.RequireCapability to route clients without elicitation to a fallback.
+type Approver struct {
+	// Label is a human-friendly name.
+	Label string
+	// Timeout bounds the wait for the user (zero waits for the tool timeout).
+	Timeout time.Duration
+	// Renderer localizes the summary.
+	Renderer templates.Renderer
+}
+
+// Name returns approver name for audit and logging.
+func (a Approver) Name() string {
+	if a.Label != "" {
+		return a.Label
+	}
+	return "elicitation"
+}
+
+// Approve asks the user to accept or decline the call; a comment entered in the form is added to the reason.
+func (a Approver) Approve(ctx context.Context, req approver.Request) (approver.Decision, error) {
+	if !approver.ClientSupports(req.Session, approver.CapabilityElicitation) {
+		return approver.Decision{Allowed: false, Reason: "mcp client does not support elicitation", Source: a.Name()}, nil
+	}
+
+	if a.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.Timeout)
+		defer cancel()
+	}
+	result, err := req.Session.Elicit(ctx, &mcp.ElicitParams{
+		Mode:    "form",
+		Message: a.message(req),
+		RequestedSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"comment": map[string]any{"type": "string", "title": "Comment", "description": "Optional comment for the audit log"},
+			},
+		},
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return approver.Decision{Allowed: false, Reason: "approval timed out", Source: a.Name()}, nil
+		}
+		return approver.Decision{Allowed: false, Reason: "elicitation failed", Source: a.Name()}, err
+	}
+
+	comment, _ := result.Content["comment"].(string)
+	comment = strings.TrimSpace(comment)
+	switch result.Action {
+	case "accept":
+		return approver.Decision{Allowed: true, Reason: withComment("approved by user", comment), Source: a.Name()}, nil
+	case "decline":
+		return approver.Decision{Allowed: false, Reason: withComment("declined by user", comment), Source: a.Name()}, nil
+	default:
+		return approver.Decision{Allowed: false, Reason: "approval dismissed by user", Source: a.Name()}, nil
+	}
+}
+
+// message renders the approval summary shown to the user.
+func (a Approver) message(req approver.Request) string {
+	rest := approver.ToolArgs(req.Arguments)
+	arguments, err := json.MarshalIndent(rest, "", "  ")
+	if err != nil {
+		arguments = []byte(fmt.Sprint(rest))
+	}
+	data := map[string]any{
+		"Tool":            req.ToolName,
+		"CorrelationID":   req.CorrelationID,
+		"Justification":   approver.StringArg(req.Arguments, "justification"),
+		"ApprovalRequest": approver.StringArg(req.Arguments, "approval_request"),
+		"RiskAssessment":  approver.StringArg(req.Arguments, "risk_assessment"),
+		"Arguments":       string(arguments),
+		"Diff":            req.Diff,
+	}
+	if a.Renderer != nil {
+		if rendered, err := a.Renderer.Render("elicitation.message", data); err == nil {
+			return rendered
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Approve %s?\n", req.ToolName)
+	for _, line := range [][2]string{
+		{"Request", approver.StringArg(req.Arguments, "approval_request")},
+		{"Justification", approver.StringArg(req.Arguments, "justification")},
+		{"Risks", approver.StringArg(req.Arguments, "risk_assessment")},
+	} {
+		if line[1] != "" {
+			fmt.Fprintf(&b, "%s: %s\n", line[0], line[1])
+		}
+	}
+	fmt.Fprintf(&b, "Arguments:\n%s\n", arguments)
+	if req.Diff != "" {
+		fmt.Fprintf(&b, "Changes:\n%s\n", req.Diff)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func withComment(reason, comment string) string {
+	if comment == "" {
+		return reason
+	}
+	return reason + ": " + comment
+}
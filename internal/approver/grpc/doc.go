@@ -0,0 +1,2 @@
+// Package grpc implements a gRPC-based approver client.
+package grpc
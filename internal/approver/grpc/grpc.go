@@ -0,0 +1,162 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	yamlmcpv1 "github.com/codex-k8s/yaml-mcp-server/api/yamlmcp/v1"
+	"github.com/codex-k8s/yaml-mcp-server/internal/grpcclient"
+	"github.com/codex-k8s/yaml-mcp-server/internal/protocol"
+	"github.com/codex-k8s/yaml-mcp-server/internal/runtime/approver"
+	"github.com/codex-k8s/yaml-mcp-server/internal/secrets"
+)
+
+// Client calls external gRPC approvers.
+type Client struct {
+	// Label is a human-friendly name.
+	Label string
+	// Service is the generated approver service client.
+	Service yamlmcpv1.ApproverServiceClient
+	// Headers are sent as gRPC metadata.
+	Headers map[string]string
+	// Timeout limits unary calls (streams are bounded by the tool deadline).
+	Timeout time.Duration
+	// Stream uses ApproveStream instead of the unary Approve call.
+	Stream bool
+	// Lang defines the preferred language for approver messages.
+	Lang string
+	// Markup selects approval message markup (markdown/html).
+	Markup string
+	// SecretHeaders adds metadata resolved from secrets at call time.
+	SecretHeaders map[string]secrets.Ref
+	// Secrets resolves SecretHeaders references.
+	Secrets *secrets.Store
+}
+
+// Name returns approver name for audit and logging.
+func (c Client) Name() string {
+	if c.Label != "" {
+		return c.Label
+	}
+	return "grpc"
+}
+
+// Approve sends the request to the gRPC approver and maps its decision.
+func (c Client) Approve(ctx context.Context, req approver.Request) (approver.Decision, error) {
+	if c.Service == nil {
+		return approver.Decision{Allowed: false, Reason: "grpc approver is not configured", Source: c.Name()}, nil
+	}
+	arguments, err := grpcclient.ToStruct(req.Arguments)
+	if err != nil {
+		return approver.Decision{Allowed: false, Reason: "failed to encode request", Source: c.Name()}, err
+	}
+	payload := &yamlmcpv1.ApproverRequest{
+		CorrelationId:   req.CorrelationID,
+		Tool:            req.ToolName,
+		Arguments:       arguments,
+		Justification:   approver.StringArg(req.Arguments, "justification"),
+		ApprovalRequest: approver.StringArg(req.Arguments, "approval_request"),
+		RiskAssessment:  approver.StringArg(req.Arguments, "risk_assessment"),
+		LinksToCode:     links(req.Arguments),
+		Diff:            req.Diff,
+		Lang:            c.Lang,
+		Markup:          c.Markup,
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		payload.TimeoutSec = int32(max(1, int(time.Until(deadline).Seconds())))
+	}
+
+	secretHeaders, err := c.Secrets.Resolve(ctx, c.SecretHeaders)
+	if err != nil {
+		return approver.Decision{Allowed: false, Reason: "failed to resolve secrets", Source: c.Name()}, err
+	}
+	ctx = grpcclient.WithHeaders(ctx, c.Headers)
+	ctx = grpcclient.WithHeaders(ctx, secretHeaders)
+	var resp *yamlmcpv1.ApproverResponse
+	if c.Stream {
+		resp, err = c.awaitStream(ctx, payload)
+	} else {
+		callCtx := ctx
+		if c.Timeout > 0 {
+			var cancel context.CancelFunc
+			callCtx, cancel = context.WithTimeout(ctx, c.Timeout)
+			defer cancel()
+		}
+		resp, err = c.Service.Approve(callCtx, payload)
+	}
+	if err != nil {
+		return approver.Decision{Allowed: false, Reason: "approver request failed", Source: c.Name()}, err
+	}
+	return c.decision(resp)
+}
+
+func (c Client) awaitStream(ctx context.Context, payload *yamlmcpv1.ApproverRequest) (*yamlmcpv1.ApproverResponse, error) {
+	stream, err := c.Service.ApproveStream(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("approver stream closed without a final decision")
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch event.GetType() {
+		case yamlmcpv1.EventType_EVENT_TYPE_FINAL:
+			if event.GetResponse() == nil {
+				return nil, errors.New("approver final event has no response")
+			}
+			return event.GetResponse(), nil
+		default:
+			// Pending and progress events keep waiting for the final decision.
+		}
+	}
+}
+
+func (c Client) decision(resp *yamlmcpv1.ApproverResponse) (approver.Decision, error) {
+	decision := strings.ToLower(strings.TrimSpace(resp.GetDecision()))
+	switch decision {
+	case protocol.DecisionApprove:
+		return approver.Decision{Allowed: true, Reason: fallbackReason(resp.GetReason(), "approved"), Source: c.Name()}, nil
+	case protocol.DecisionDeny:
+		return approver.Decision{Allowed: false, Reason: fallbackReason(resp.GetReason(), "denied"), Source: c.Name()}, nil
+	case protocol.DecisionError:
+		return approver.Decision{Allowed: false, Reason: fallbackReason(resp.GetReason(), "approver error"), Source: c.Name()}, nil
+	default:
+		return approver.Decision{Allowed: false, Reason: "unknown approver decision", Source: c.Name()}, fmt.Errorf("unknown approver decision: %s", decision)
+	}
+}
+
+func links(args map[string]any) []*yamlmcpv1.ApproverLink {
+	items, _ := args["links_to_code"].([]any)
+	out := make([]*yamlmcpv1.ApproverLink, 0, len(items))
+	for _, item := range items {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		text, _ := obj["text"].(string)
+		url, _ := obj["url"].(string)
+		if strings.TrimSpace(text) == "" || strings.TrimSpace(url) == "" {
+			continue
+		}
+		out = append(out, &yamlmcpv1.ApproverLink{Text: strings.TrimSpace(text), Url: strings.TrimSpace(url)})
+		if len(out) == 5 {
+			break
+		}
+	}
+	return out
+}
+
+func fallbackReason(reason, fallback string) string {
+	if strings.TrimSpace(reason) == "" {
+		return fallback
+	}
+	return reason
+}
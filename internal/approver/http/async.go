@@ -3,67 +3,24 @@ package http
 import (
 	"encoding/json"
 	"errors"
+	"io"
 	"log/slog"
 	"net/http"
 	"strings"
-	"sync"
+	"time"
 
-	"github.com/codex-k8s/yaml-mcp-server/internal/maputil"
+	"github.com/codex-k8s/yaml-mcp-server/internal/pending"
 	"github.com/codex-k8s/yaml-mcp-server/internal/protocol"
 	"github.com/codex-k8s/yaml-mcp-server/internal/runtime/approver"
 )
 
-// PendingStore keeps async approval results.
-type PendingStore struct {
-	mu      sync.Mutex
-	pending map[string]*pendingApproval
-}
-
-type pendingApproval struct {
-	ch     chan approver.Decision
-	source string
-}
-
-// NewPendingStore creates a new async approval store.
-func NewPendingStore() *PendingStore {
-	return &PendingStore{pending: make(map[string]*pendingApproval)}
-}
-
-// Register allocates a pending slot for correlationID.
-func (s *PendingStore) Register(correlationID, source string) (<-chan approver.Decision, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	if _, exists := s.pending[correlationID]; exists {
-		return nil, errAlreadyPending
-	}
-	ch := make(chan approver.Decision, 1)
-	s.pending[correlationID] = &pendingApproval{ch: ch, source: source}
-	return ch, nil
-}
+// PendingStore keeps async approvals until a decision webhook arrives.
+type PendingStore = pending.Store[approver.Decision]
 
-// Resolve delivers a decision for correlationID.
-func (s *PendingStore) Resolve(correlationID string, decision approver.Decision) bool {
-	entry, ok := maputil.Pop(&s.mu, s.pending, correlationID)
-	if !ok {
-		return false
-	}
-	if decision.Source == "" {
-		decision.Source = entry.source
-	}
-	select {
-	case entry.ch <- decision:
-	default:
-	}
-	close(entry.ch)
-	return true
-}
-
-// Cancel removes a pending approval without a decision.
-func (s *PendingStore) Cancel(correlationID string) {
-	entry, ok := maputil.Pop(&s.mu, s.pending, correlationID)
-	if ok {
-		close(entry.ch)
-	}
+// NewPendingStore creates an async approval store on top of backend (in-memory when nil),
+// keeping sent requests for ttl (pending.DefaultTTL when not positive).
+func NewPendingStore(backend pending.Backend, ttl time.Duration) *PendingStore {
+	return pending.NewStore[approver.Decision](backend, ttl)
 }
 
 // WebhookHandler handles async approval callbacks.
@@ -82,9 +39,13 @@ func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
 	var payload protocol.ApproverDecision
-	decoder := json.NewDecoder(r.Body)
-	if err := decoder.Decode(&payload); err != nil {
+	if err := json.Unmarshal(body, &payload); err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
@@ -95,20 +56,41 @@ func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	switch decision {
-	case protocol.DecisionApprove, protocol.DecisionDeny, protocol.DecisionError:
+	case protocol.DecisionApprove, protocol.DecisionDeny, protocol.DecisionError, protocol.DecisionNeedsInfo:
 	default:
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
+	if err := h.Store.Authorize(r, body, correlationID, payload.Token); err != nil {
+		if h.Logger != nil {
+			h.Logger.Warn("approval webhook rejected", "correlation_id", correlationID, "error", err)
+		}
+		if errors.Is(err, pending.ErrNotPending) {
+			w.WriteHeader(http.StatusNotFound)
+		} else {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+		return
+	}
+
 	var resolved bool
 	switch decision {
 	case protocol.DecisionApprove:
-		resolved = h.Store.Resolve(correlationID, approver.Decision{Allowed: true, Reason: payload.Reason})
+		resolved, err = h.Store.Resolve(correlationID, approver.Decision{Allowed: true, Reason: payload.Reason, Patch: payload.Patch})
 	case protocol.DecisionDeny:
-		resolved = h.Store.Resolve(correlationID, approver.Decision{Allowed: false, Reason: fallbackReason(payload.Reason, "denied")})
+		resolved, err = h.Store.Resolve(correlationID, approver.Decision{Allowed: false, Reason: fallbackReason(payload.Reason, "denied")})
 	case protocol.DecisionError:
-		resolved = h.Store.Resolve(correlationID, approver.Decision{Allowed: false, Reason: fallbackReason(payload.Reason, "approver error")})
+		resolved, err = h.Store.Resolve(correlationID, approver.Decision{Allowed: false, Reason: fallbackReason(payload.Reason, "approver error")})
+	case protocol.DecisionNeedsInfo:
+		resolved, err = h.Store.Resolve(correlationID, approver.NeedsInfo(payload.Reason, payload.Questions, ""))
+	}
+	if err != nil {
+		if h.Logger != nil {
+			h.Logger.Error("approval webhook failed", "correlation_id", correlationID, "error", err)
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
 	}
 	if !resolved {
 		if h.Logger != nil {
@@ -120,4 +102,4 @@ func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
-var errAlreadyPending = errors.New("approval already pending")
+const maxWebhookBody = 1 << 20
@@ -11,8 +11,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/codex-k8s/yaml-mcp-server/internal/breaker"
+	"github.com/codex-k8s/yaml-mcp-server/internal/httpclient"
+	"github.com/codex-k8s/yaml-mcp-server/internal/pending"
 	"github.com/codex-k8s/yaml-mcp-server/internal/protocol"
+	"github.com/codex-k8s/yaml-mcp-server/internal/retry"
 	"github.com/codex-k8s/yaml-mcp-server/internal/runtime/approver"
+	"github.com/codex-k8s/yaml-mcp-server/internal/secrets"
+	"github.com/codex-k8s/yaml-mcp-server/internal/webhookauth"
 )
 
 // Client calls external HTTP approvers.
@@ -37,6 +43,26 @@ type Client struct {
 	Markup string
 	// Pending stores async approvals.
 	Pending *PendingStore
+	// Retry retries the request on transient failures (single attempt when nil).
+	Retry *retry.Policy
+	// OnRetry is called before each retry (optional).
+	OnRetry func(ctx context.Context, req approver.Request, attempt retry.Attempt)
+	// Endpoints adds failover URLs and circuit breakers (plain URL when nil).
+	Endpoints *breaker.Endpoints
+	// Transport applies an http_clients profile (default transport when nil).
+	Transport http.RoundTripper
+	// WebhookAuth verifies signed decision callbacks (token-only check when nil).
+	WebhookAuth *webhookauth.Verifier
+	// SecretHeaders adds headers resolved from secrets at call time.
+	SecretHeaders map[string]secrets.Ref
+	// Secrets resolves SecretHeaders references.
+	Secrets *secrets.Store
+	// CancelURL is notified when the call ends by cancellation or timeout after the request was sent.
+	CancelURL string
+	// OnCancel is called after each cancellation notice (optional).
+	OnCancel func(ctx context.Context, correlationID string, err error)
+	// PatchableFields lists the arguments a decision may patch (no patches when empty).
+	PatchableFields []string
 }
 
 // Name returns approver name for audit and logging.
@@ -62,14 +88,22 @@ func (c Client) Approve(ctx context.Context, req approver.Request) (approver.Dec
 	}
 
 	payload := protocol.ApproverRequest{
-		CorrelationID: req.CorrelationID,
-		Tool:          req.ToolName,
-		Arguments:     req.Arguments,
-		Lang:          c.Lang,
-		Markup:        c.Markup,
-	}
+		CorrelationID:   req.CorrelationID,
+		Tool:            req.ToolName,
+		Arguments:       req.Arguments,
+		Diff:            req.Diff,
+		Lang:            c.Lang,
+		Markup:          c.Markup,
+		PatchableFields: c.PatchableFields,
+		Metadata:        req.Metadata,
+	}
+	if req.FollowUp != nil {
+		payload.FollowUp = &protocol.ApproverFollowUp{Approver: req.FollowUp.Source, Reason: req.FollowUp.Reason, Questions: req.FollowUp.Questions}
+	}
+	var callbackToken string
 	if c.Async {
-		payload.Callback = &protocol.ApproverCallback{URL: c.WebhookURL}
+		callbackToken = webhookauth.NewToken()
+		payload.Callback = &protocol.ApproverCallback{URL: c.WebhookURL, Token: callbackToken}
 		if c.Timeout > 0 {
 			payload.TimeoutSec = int(c.Timeout.Seconds())
 		}
@@ -121,46 +155,110 @@ func (c Client) Approve(ctx context.Context, req approver.Request) (approver.Dec
 		method = http.MethodPost
 	}
 
-	request, err := http.NewRequestWithContext(ctx, method, c.URL, bytes.NewReader(body))
+	secretHeaders, err := c.Secrets.Resolve(ctx, c.SecretHeaders)
 	if err != nil {
-		return approver.Decision{Allowed: false, Reason: "failed to build request", Source: c.Name()}, err
-	}
-	request.Header.Set("Content-Type", "application/json")
-	for key, value := range c.Headers {
-		request.Header.Set(key, value)
+		return approver.Decision{Allowed: false, Reason: "failed to resolve secrets", Source: c.Name()}, err
 	}
 
-	client := &http.Client{Timeout: c.Timeout}
+	client := &http.Client{Timeout: c.Timeout, Transport: c.Transport}
 
-	var pendingCh <-chan approver.Decision
+	var (
+		pendingCh <-chan approver.Decision
+		sent      bool
+	)
+	defer func() {
+		if sent && ctx.Err() != nil {
+			c.cancel(ctx, req, secretHeaders)
+		}
+	}()
 	if c.Async {
-		ch, err := c.Pending.Register(req.CorrelationID, c.Name())
+		argsHash, err := pending.ArgumentsHash(approver.ToolArgs(req.Arguments))
+		if err != nil {
+			return approver.Decision{Allowed: false, Reason: "failed to encode request", Source: c.Name()}, err
+		}
+		ch, state, err := c.Pending.Register(pending.Record{
+			CorrelationID: req.CorrelationID,
+			Source:        c.Name(),
+			Tool:          req.ToolName,
+			ArgsHash:      argsHash,
+			Token:         callbackToken,
+			Deadline:      pending.Deadline(ctx, c.Timeout),
+		}, c.WebhookAuth)
+		if errors.Is(err, pending.ErrMismatch) {
+			return approver.Decision{Allowed: false, Reason: "correlation_id is already used by a different call", Source: c.Name()}, nil
+		}
 		if err != nil {
 			return approver.Decision{Allowed: false, Reason: "approval already pending", Source: c.Name()}, err
 		}
 		pendingCh = ch
-		defer c.Pending.Cancel(req.CorrelationID)
+		defer func() {
+			// A sent request outlives a timed-out call (for the pending store TTL) unless it
+			// is cancelled remotely, so a retry can pick up a late decision.
+			if sent && ctx.Err() != nil && strings.TrimSpace(c.CancelURL) == "" {
+				c.Pending.Cancel(req.CorrelationID)
+				return
+			}
+			_ = c.Pending.Complete(req.CorrelationID)
+		}()
+		if state != pending.StateNew {
+			sent = true
+			return c.awaitDecision(ctx, pendingCh)
+		}
 	}
 
-	resp, err := client.Do(request)
+	// Only the request itself is retried; waiting for an async decision is not.
+	var (
+		statusCode int
+		data       []byte
+	)
+	endpoints := c.Endpoints
+	if endpoints == nil {
+		endpoints = breaker.Direct(c.URL)
+	}
+	err = c.Retry.Do(ctx, func(ctx context.Context) error {
+		return endpoints.Do(ctx, func(ctx context.Context, url string) error {
+			request, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+			if err != nil {
+				return err
+			}
+			request.Header.Set("Content-Type", "application/json")
+			for key, value := range c.Headers {
+				request.Header.Set(key, value)
+			}
+			for key, value := range secretHeaders {
+				request.Header.Set(key, value)
+			}
+			sent = true
+			resp, err := client.Do(request)
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+			statusCode = resp.StatusCode
+			data, _ = io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+			if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+				return &retry.StatusError{
+					Code:       resp.StatusCode,
+					RetryAfter: retry.ParseRetryAfter(resp.Header.Get("Retry-After")),
+					Message:    fmt.Sprintf("approver status %d: %s", resp.StatusCode, strings.TrimSpace(string(data))),
+				}
+			}
+			return nil
+		})
+	}, func(attempt retry.Attempt) {
+		if c.OnRetry != nil {
+			c.OnRetry(ctx, req, attempt)
+		}
+	})
+	var statusErr *retry.StatusError
+	if errors.As(err, &statusErr) {
+		return approver.Decision{Allowed: false, Reason: statusErr.Message, Source: c.Name()}, nil
+	}
 	if err != nil {
 		return approver.Decision{Allowed: false, Reason: "approver request failed", Source: c.Name()}, err
 	}
-	defer resp.Body.Close()
-
-	data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		if c.Async && resp.StatusCode == http.StatusAccepted {
-			return c.awaitDecision(ctx, pendingCh)
-		}
-		return approver.Decision{
-			Allowed: false,
-			Reason:  fmt.Sprintf("approver status %d: %s", resp.StatusCode, strings.TrimSpace(string(data))),
-			Source:  c.Name(),
-		}, nil
-	}
 
-	if c.Async && resp.StatusCode == http.StatusAccepted && len(bytes.TrimSpace(data)) == 0 {
+	if c.Async && statusCode == http.StatusAccepted && len(bytes.TrimSpace(data)) == 0 {
 		return c.awaitDecision(ctx, pendingCh)
 	}
 
@@ -172,11 +270,14 @@ func (c Client) Approve(ctx context.Context, req approver.Request) (approver.Dec
 	decision := strings.ToLower(strings.TrimSpace(parsed.Decision))
 	switch decision {
 	case protocol.DecisionApprove:
-		return approver.Decision{Allowed: true, Reason: fallbackReason(parsed.Reason, "approved"), Source: c.Name()}, nil
+		approved := approver.Decision{Allowed: true, Reason: fallbackReason(parsed.Reason, "approved"), Source: c.Name(), Patch: parsed.Patch}
+		return approver.LimitPatch(approved, c.PatchableFields), nil
 	case protocol.DecisionDeny:
 		return approver.Decision{Allowed: false, Reason: fallbackReason(parsed.Reason, "denied"), Source: c.Name()}, nil
 	case protocol.DecisionError:
 		return approver.Decision{Allowed: false, Reason: fallbackReason(parsed.Reason, "approver error"), Source: c.Name()}, nil
+	case protocol.DecisionNeedsInfo:
+		return approver.NeedsInfo(parsed.Reason, parsed.Questions, c.Name()), nil
 	case protocol.DecisionPending:
 		if c.Async {
 			return c.awaitDecision(ctx, pendingCh)
@@ -187,6 +288,17 @@ func (c Client) Approve(ctx context.Context, req approver.Request) (approver.Dec
 	}
 }
 
+// cancel notifies CancelURL that the call was cancelled or timed out.
+func (c Client) cancel(ctx context.Context, req approver.Request, secretHeaders map[string]string) {
+	if strings.TrimSpace(c.CancelURL) == "" {
+		return
+	}
+	err := httpclient.Cancel(ctx, c.Transport, c.CancelURL, req.CorrelationID, req.ToolName, c.Headers, secretHeaders)
+	if c.OnCancel != nil {
+		c.OnCancel(ctx, req.CorrelationID, err)
+	}
+}
+
 func fallbackReason(reason, fallback string) string {
 	if strings.TrimSpace(reason) == "" {
 		return fallback
@@ -206,7 +318,7 @@ func (c Client) awaitDecision(ctx context.Context, pendingCh <-chan approver.Dec
 		if decision.Source == "" {
 			decision.Source = c.Name()
 		}
-		return decision, nil
+		return approver.LimitPatch(decision, c.PatchableFields), nil
 	case <-ctx.Done():
 		return approver.Decision{Allowed: false, Reason: "approval timeout", Source: c.Name()}, ctx.Err()
 	}
@@ -0,0 +1,2 @@
+// Package plugin implements an approver backed by a long-lived subprocess plugin.
+package plugin
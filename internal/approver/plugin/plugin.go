@@ -0,0 +1,82 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/plugin"
+	"github.com/codex-k8s/yaml-mcp-server/internal/protocol"
+	"github.com/codex-k8s/yaml-mcp-server/internal/runtime/approver"
+)
+
+// Approver sends approval requests to a plugin process over JSON-RPC.
+type Approver struct {
+	// Label is a human-friendly name.
+	Label string
+	// Process is the plugin process handling requests.
+	Process *plugin.Process
+	// Lang defines the preferred language for approver messages.
+	Lang string
+	// Markup selects approval message markup (markdown/html).
+	Markup string
+	// PatchableFields lists the arguments a decision may patch (no patches when empty).
+	PatchableFields []string
+}
+
+// Name returns approver name for audit and logging.
+func (a Approver) Name() string {
+	if a.Label != "" {
+		return a.Label
+	}
+	return "plugin"
+}
+
+// Approve calls the plugin "approve" method and maps its decision.
+func (a Approver) Approve(ctx context.Context, req approver.Request) (approver.Decision, error) {
+	if a.Process == nil {
+		return approver.Decision{Allowed: false, Reason: "plugin is not configured", Source: a.Name()}, nil
+	}
+	payload := protocol.ApproverRequest{
+		CorrelationID:   req.CorrelationID,
+		Tool:            req.ToolName,
+		Arguments:       req.Arguments,
+		Justification:   approver.StringArg(req.Arguments, "justification"),
+		ApprovalRequest: approver.StringArg(req.Arguments, "approval_request"),
+		RiskAssessment:  approver.StringArg(req.Arguments, "risk_assessment"),
+		Diff:            req.Diff,
+		Lang:            a.Lang,
+		Markup:          a.Markup,
+		PatchableFields: a.PatchableFields,
+		Metadata:        req.Metadata,
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		payload.TimeoutSec = max(1, int(time.Until(deadline).Seconds()))
+	}
+
+	var resp protocol.ApproverResponse
+	if err := a.Process.Call(ctx, plugin.MethodApprove, payload, &resp); err != nil {
+		return approver.Decision{Allowed: false, Reason: "plugin call failed", Source: a.Name()}, err
+	}
+
+	decision := strings.ToLower(strings.TrimSpace(resp.Decision))
+	switch decision {
+	case protocol.DecisionApprove:
+		approved := approver.Decision{Allowed: true, Reason: fallbackReason(resp.Reason, "approved"), Source: a.Name(), Patch: resp.Patch}
+		return approver.LimitPatch(approved, a.PatchableFields), nil
+	case protocol.DecisionDeny:
+		return approver.Decision{Allowed: false, Reason: fallbackReason(resp.Reason, "denied"), Source: a.Name()}, nil
+	case protocol.DecisionError:
+		return approver.Decision{Allowed: false, Reason: fallbackReason(resp.Reason, "approver error"), Source: a.Name()}, nil
+	default:
+		return approver.Decision{Allowed: false, Reason: "unknown approver decision", Source: a.Name()}, fmt.Errorf("unknown approver decision: %s", decision)
+	}
+}
+
+func fallbackReason(reason, fallback string) string {
+	if strings.TrimSpace(reason) == "" {
+		return fallback
+	}
+	return reason
+}
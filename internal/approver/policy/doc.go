@@ -0,0 +1,2 @@
+// Package policy provides an approver that evaluates ordered CEL allow/deny rules.
+package policy
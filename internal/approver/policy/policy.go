@@ -0,0 +1,140 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/condition"
+	"github.com/codex-k8s/yaml-mcp-server/internal/constants"
+	"github.com/codex-k8s/yaml-mcp-server/internal/executil"
+	"github.com/codex-k8s/yaml-mcp-server/internal/runtime/approver"
+)
+
+// RuleSpec declares a policy rule.
+type RuleSpec struct {
+	// Name identifies the rule in results (defaults to rules[<index>]).
+	Name string
+	// When is a CEL expression; the first matching rule decides.
+	When string
+	// Effect is allow or deny.
+	Effect string
+	// Reason is a template rendered with tool arguments ({{ .Args.<name> }}).
+	Reason string
+}
+
+type rule struct {
+	spec    RuleSpec
+	program *condition.Program
+}
+
+// Result is the outcome of evaluating the rules.
+type Result struct {
+	// Allowed is the effect of the matched rule or the default.
+	Allowed bool
+	// Reason is the rendered reason.
+	Reason string
+	// Rule is the matched rule name (empty when the default applied).
+	Rule string
+}
+
+// Approver allows or denies calls by the first matching rule.
+type Approver struct {
+	// Label is a human-friendly name.
+	Label string
+	// Tool exposes name, title, tags, metadata and annotations to rules.
+	Tool map[string]any
+	// Now overrides the evaluation time (time.Now when nil).
+	Now func() time.Time
+
+	rules        []rule
+	defaultAllow bool
+}
+
+// New compiles rules; defaultEffect applies when no rule matches (deny when empty).
+func New(label string, specs []RuleSpec, defaultEffect string, tool map[string]any) (*Approver, error) {
+	rules := make([]rule, 0, len(specs))
+	for i, spec := range specs {
+		if strings.TrimSpace(spec.Name) == "" {
+			spec.Name = fmt.Sprintf("rules[%d]", i)
+		}
+		program, err := condition.Compile(spec.When)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %w", spec.Name, err)
+		}
+		rules = append(rules, rule{spec: spec, program: program})
+	}
+	return &Approver{
+		Label:        label,
+		Tool:         tool,
+		rules:        rules,
+		defaultAllow: strings.EqualFold(strings.TrimSpace(defaultEffect), constants.PolicyAllow),
+	}, nil
+}
+
+// Name returns approver name for audit and logging.
+func (a *Approver) Name() string {
+	if a.Label != "" {
+		return a.Label
+	}
+	return "policy"
+}
+
+// Approve evaluates the rules against the request.
+func (a *Approver) Approve(_ context.Context, req approver.Request) (approver.Decision, error) {
+	now := time.Now()
+	if a.Now != nil {
+		now = a.Now()
+	}
+	result := a.Evaluate(req, now)
+	return approver.Decision{Allowed: result.Allowed, Reason: result.Reason, Source: a.Name()}, nil
+}
+
+// Evaluate returns the first matching rule's effect; evaluation errors deny.
+func (a *Approver) Evaluate(req approver.Request, now time.Time) Result {
+	vars := condition.Vars{
+		Args: req.Arguments,
+		Tool: a.Tool,
+		Caller: map[string]any{
+			"name":       req.Caller.Name,
+			"version":    req.Caller.Version,
+			"session_id": req.Caller.SessionID,
+		},
+		Now: now,
+	}
+	for _, item := range a.rules {
+		matched, err := item.program.Eval(vars)
+		if err != nil {
+			return Result{Allowed: false, Reason: fmt.Sprintf("policy rule %s failed: %s", item.spec.Name, err), Rule: item.spec.Name}
+		}
+		if !matched {
+			continue
+		}
+		allowed := strings.EqualFold(strings.TrimSpace(item.spec.Effect), constants.PolicyAllow)
+		return Result{Allowed: allowed, Reason: a.reason(item.spec, req, allowed), Rule: item.spec.Name}
+	}
+	if a.defaultAllow {
+		return Result{Allowed: true, Reason: "no policy rule matched; allowed by default"}
+	}
+	return Result{Allowed: false, Reason: "no policy rule matched; denied by default"}
+}
+
+func (a *Approver) reason(spec RuleSpec, req approver.Request, allowed bool) string {
+	fallback := fmt.Sprintf("denied by policy rule %s", spec.Name)
+	if allowed {
+		fallback = fmt.Sprintf("allowed by policy rule %s", spec.Name)
+	}
+	if strings.TrimSpace(spec.Reason) == "" {
+		return fallback
+	}
+	rendered, err := executil.RenderTemplate(spec.Reason, executil.TemplateData{
+		Args:          req.Arguments,
+		ToolName:      req.ToolName,
+		CorrelationID: req.CorrelationID,
+	})
+	if err != nil {
+		return fmt.Sprintf("%s (reason template failed: %s)", fallback, err)
+	}
+	return strings.TrimSpace(rendered)
+}
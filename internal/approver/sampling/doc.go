@@ -0,0 +1,3 @@
+// Package sampling provides an approver that asks the client's model to review a call via
+// sampling/createMessage before a human is asked.
+package sampling
@@ -0,0 +1,185 @@
+package sampling
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/runtime/approver"
+)
+
+// Reviewer verdicts.
+const (
+	VerdictApprove  = "approve"
+	VerdictConcerns = "concerns"
+	VerdictDeny     = "deny"
+)
+
+// DefaultPrompt is the reviewer prompt used when none is configured.
+const DefaultPrompt = "You review tool calls requested by an AI agent before a human approves them. " +
+	"Check that the arguments match the justification and the requested action, and look for destructive " +
+	"or unexpected effects. Use deny only for calls that are clearly unsafe or do not match their justification; " +
+	"use concerns when a human should look at something specific."
+
+// formatPrompt is appended to every reviewer prompt so the verdict can be parsed.
+const formatPrompt = `Reply with a single JSON object and nothing else: ` +
+	`{"verdict": "approve" | "concerns" | "deny", "reason": "one sentence", "concerns": ["..."]}`
+
+// defaultMaxTokens bounds the reviewer reply when MaxTokens is not set.
+const defaultMaxTokens = 1024
+
+// Verdict is the reviewer's structured reply.
+type Verdict struct {
+	// Verdict is approve, concerns or deny.
+	Verdict string `json:"verdict"`
+	// Reason summarizes the review.
+	Reason string `json:"reason"`
+	// Concerns list what a human approver should check.
+	Concerns []string `json:"concerns,omitempty"`
+}
+
+// Approver asks the client's model for a verdict: deny stops the chain, approve and concerns pass the
+// call to the next approver with the review in metadata. Wrap it in approver.RequireCapability to route
+// clients without sampling to a fallback.
+type Approver struct {
+	// Label is a human-friendly name.
+	Label string
+	// Prompt is the reviewer system prompt (DefaultPrompt when empty).
+	Prompt string
+	// MaxTokens bounds the reviewer reply.
+	MaxTokens int64
+	// Timeout bounds the review (zero waits for the tool timeout).
+	Timeout time.Duration
+}
+
+// Name returns approver name for audit and logging.
+func (a Approver) Name() string {
+	if a.Label != "" {
+		return a.Label
+	}
+	return "sampling"
+}
+
+// Approve requests a review from the client's model and maps the verdict to a decision.
+func (a Approver) Approve(ctx context.Context, req approver.Request) (approver.Decision, error) {
+	if !approver.ClientSupports(req.Session, approver.CapabilitySampling) {
+		return approver.Decision{Allowed: false, Reason: "mcp client does not support sampling", Source: a.Name()}, nil
+	}
+
+	if a.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.Timeout)
+		defer cancel()
+	}
+	message, err := reviewMessage(req)
+	if err != nil {
+		return approver.Decision{Allowed: false, Reason: "review request failed", Source: a.Name()}, err
+	}
+	prompt := strings.TrimSpace(a.Prompt)
+	if prompt == "" {
+		prompt = DefaultPrompt
+	}
+	maxTokens := a.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = defaultMaxTokens
+	}
+	result, err := req.Session.CreateMessage(ctx, &mcp.CreateMessageParams{
+		SystemPrompt: prompt + "\n\n" + formatPrompt,
+		Messages:     []*mcp.SamplingMessage{{Role: "user", Content: &mcp.TextContent{Text: message}}},
+		MaxTokens:    maxTokens,
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return approver.Decision{Allowed: false, Reason: "review timed out", Source: a.Name()}, nil
+		}
+		return approver.Decision{Allowed: false, Reason: "review failed", Source: a.Name()}, err
+	}
+
+	verdict, err := parseVerdict(result.Content)
+	if err != nil {
+		return approver.Decision{Allowed: false, Reason: "invalid reviewer verdict: " + err.Error(), Source: a.Name()}, nil
+	}
+	decision := approver.Decision{
+		Allowed:  verdict.Verdict != VerdictDeny,
+		Reason:   verdict.Reason,
+		Source:   a.Name(),
+		Metadata: map[string]any{"review": verdict.metadata(result.Model)},
+	}
+	if decision.Reason == "" {
+		decision.Reason = "reviewer verdict: " + verdict.Verdict
+	}
+	return decision, nil
+}
+
+// reviewMessage renders the call as the JSON document the reviewer reads.
+func reviewMessage(req approver.Request) (string, error) {
+	review := map[string]any{
+		"tool":      req.ToolName,
+		"arguments": approver.ToolArgs(req.Arguments),
+	}
+	for _, key := range []string{"justification", "approval_request", "risk_assessment"} {
+		if value := approver.StringArg(req.Arguments, key); value != "" {
+			review[key] = value
+		}
+	}
+	if req.Diff != "" {
+		review["diff"] = req.Diff
+	}
+	data, err := json.MarshalIndent(review, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return "Review this tool call:\n" + string(data), nil
+}
+
+// parseVerdict reads the verdict JSON, tolerating code fences and text around the object.
+func parseVerdict(content mcp.Content) (Verdict, error) {
+	text, ok := content.(*mcp.TextContent)
+	if !ok {
+		return Verdict{}, errors.New("reply is not text")
+	}
+	start, end := strings.Index(text.Text, "{"), strings.LastIndex(text.Text, "}")
+	if start < 0 || end < start {
+		return Verdict{}, errors.New("reply has no JSON object")
+	}
+	var verdict Verdict
+	if err := json.Unmarshal([]byte(text.Text[start:end+1]), &verdict); err != nil {
+		return Verdict{}, err
+	}
+	verdict.Verdict = strings.ToLower(strings.TrimSpace(verdict.Verdict))
+	switch verdict.Verdict {
+	case VerdictApprove, VerdictConcerns, VerdictDeny:
+	default:
+		return Verdict{}, fmt.Errorf("unknown verdict %q", verdict.Verdict)
+	}
+	verdict.Reason = strings.TrimSpace(verdict.Reason)
+	concerns := verdict.Concerns[:0]
+	for _, concern := range verdict.Concerns {
+		if concern = strings.TrimSpace(concern); concern != "" {
+			concerns = append(concerns, concern)
+		}
+	}
+	verdict.Concerns = concerns
+	return verdict, nil
+}
+
+// metadata is the review as passed to subsequent approvers.
+func (v Verdict) metadata(model string) map[string]any {
+	review := map[string]any{"verdict": v.Verdict, "reason": v.Reason}
+	if len(v.Concerns) > 0 {
+		concerns := make([]any, 0, len(v.Concerns))
+		for _, concern := range v.Concerns {
+			concerns = append(concerns, concern)
+		}
+		review["concerns"] = concerns
+	}
+	if model != "" {
+		review["model"] = model
+	}
+	return review
+}
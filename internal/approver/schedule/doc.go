@@ -0,0 +1,3 @@
+// Package schedule provides an approver that allows calls only inside time windows and outside
+// change freezes and holidays.
+package schedule
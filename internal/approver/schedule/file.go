@@ -0,0 +1,63 @@
+package schedule
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/yaml/go-yaml"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/timewindow"
+)
+
+// periodFile caches periods parsed from a file until its size or mtime changes.
+type periodFile struct {
+	path  string
+	parse func(data []byte, loc *time.Location) ([]timewindow.Period, error)
+
+	mu      sync.Mutex
+	loaded  bool
+	periods []timewindow.Period
+	modTime time.Time
+	size    int64
+}
+
+func (f *periodFile) get(loc *time.Location) ([]timewindow.Period, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("stat %s: %w", f.path, err)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.loaded && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.periods, nil
+	}
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", f.path, err)
+	}
+	periods, err := f.parse(data, loc)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", f.path, err)
+	}
+	f.loaded, f.periods, f.modTime, f.size = true, periods, info.ModTime(), info.Size()
+	return periods, nil
+}
+
+// parseFreezeFile reads a YAML or JSON list of freeze periods.
+func parseFreezeFile(data []byte, loc *time.Location) ([]timewindow.Period, error) {
+	var specs []timewindow.PeriodSpec
+	if err := yaml.Unmarshal(data, &specs); err != nil {
+		return nil, err
+	}
+	periods := make([]timewindow.Period, 0, len(specs))
+	for i, spec := range specs {
+		period, err := timewindow.ParsePeriod(spec, loc)
+		if err != nil {
+			return nil, fmt.Errorf("[%d].%w", i, err)
+		}
+		periods = append(periods, period)
+	}
+	return periods, nil
+}
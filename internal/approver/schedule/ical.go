@@ -0,0 +1,132 @@
+package schedule
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/timewindow"
+)
+
+// ParseICal reads VEVENT entries (DTSTART, DTEND, SUMMARY) as periods; recurrence rules are not expanded.
+// All-day and floating times are interpreted in loc.
+func ParseICal(data []byte, loc *time.Location) ([]timewindow.Period, error) {
+	var (
+		periods []timewindow.Period
+		inEvent bool
+		event   map[string]icalProp
+	)
+	for _, line := range unfoldICal(data) {
+		switch {
+		case strings.EqualFold(line, "BEGIN:VEVENT"):
+			inEvent, event = true, map[string]icalProp{}
+		case strings.EqualFold(line, "END:VEVENT"):
+			if !inEvent {
+				continue
+			}
+			inEvent = false
+			period, err := eventPeriod(event, loc)
+			if err != nil {
+				return nil, err
+			}
+			periods = append(periods, period)
+		case inEvent:
+			prop, ok := parseICalProp(line)
+			if ok {
+				event[prop.name] = prop
+			}
+		}
+	}
+	return periods, nil
+}
+
+type icalProp struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+func unfoldICal(data []byte) []string {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func parseICalProp(line string) (icalProp, bool) {
+	head, value, ok := strings.Cut(line, ":")
+	if !ok {
+		return icalProp{}, false
+	}
+	parts := strings.Split(head, ";")
+	prop := icalProp{name: strings.ToUpper(parts[0]), params: map[string]string{}, value: value}
+	for _, param := range parts[1:] {
+		key, val, _ := strings.Cut(param, "=")
+		prop.params[strings.ToUpper(key)] = strings.Trim(val, `"`)
+	}
+	return prop, true
+}
+
+func eventPeriod(event map[string]icalProp, loc *time.Location) (timewindow.Period, error) {
+	startProp, ok := event["DTSTART"]
+	if !ok {
+		return timewindow.Period{}, fmt.Errorf("ical: VEVENT without DTSTART")
+	}
+	start, allDay, err := icalTime(startProp, loc)
+	if err != nil {
+		return timewindow.Period{}, err
+	}
+	end := start.AddDate(0, 0, 1)
+	if !allDay {
+		end = start
+	}
+	if endProp, ok := event["DTEND"]; ok {
+		if end, _, err = icalTime(endProp, loc); err != nil {
+			return timewindow.Period{}, err
+		}
+	}
+	if !end.After(start) {
+		end = start.AddDate(0, 0, 1)
+	}
+	summary := strings.NewReplacer(`\,`, ",", `\;`, ";", `\n`, " ", `\\`, `\`).Replace(event["SUMMARY"].value)
+	return timewindow.Period{Start: start, End: end, Reason: strings.TrimSpace(summary)}, nil
+}
+
+func icalTime(prop icalProp, loc *time.Location) (time.Time, bool, error) {
+	value := strings.TrimSpace(prop.value)
+	if strings.EqualFold(prop.params["VALUE"], "DATE") || len(value) == 8 {
+		parsed, err := time.ParseInLocation("20060102", value, loc)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("ical: invalid %s: %q", prop.name, value)
+		}
+		return parsed, true, nil
+	}
+	if strings.HasSuffix(value, "Z") {
+		parsed, err := time.Parse("20060102T150405Z", value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("ical: invalid %s: %q", prop.name, value)
+		}
+		return parsed, false, nil
+	}
+	zone := loc
+	if tzid := prop.params["TZID"]; tzid != "" {
+		if named, err := time.LoadLocation(tzid); err == nil {
+			zone = named
+		}
+	}
+	parsed, err := time.ParseInLocation("20060102T150405", value, zone)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("ical: invalid %s: %q", prop.name, value)
+	}
+	return parsed, false, nil
+}
@@ -0,0 +1,200 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/runtime/approver"
+	"github.com/codex-k8s/yaml-mcp-server/internal/templates"
+	"github.com/codex-k8s/yaml-mcp-server/internal/timewindow"
+)
+
+// maxSteps bounds the search for the next open window.
+const maxSteps = 1000
+
+// nextLayout formats the next window start for deny reasons.
+const nextLayout = "2006-01-02 15:04 MST"
+
+// Approver allows calls inside time windows and outside freezes and holidays.
+type Approver struct {
+	// Label is a human-friendly name.
+	Label string
+	// Location is the time zone for windows and dates (UTC when nil).
+	Location *time.Location
+	// Windows lists allowed weekly windows (always open when empty).
+	Windows []timewindow.Window
+	// Freezes lists static freeze periods.
+	Freezes []timewindow.Period
+	// FreezeFile is a YAML/JSON list of freeze periods re-read on change.
+	FreezeFile string
+	// HolidaysFile is an iCal calendar of holidays re-read on change.
+	HolidaysFile string
+	// Renderer localizes deny reasons.
+	Renderer templates.Renderer
+	// Now overrides the current time (time.Now when nil).
+	Now func() time.Time
+
+	once         sync.Once
+	freezeFile   *periodFile
+	holidaysFile *periodFile
+}
+
+// Name returns approver name for audit and logging.
+func (a *Approver) Name() string {
+	if a.Label != "" {
+		return a.Label
+	}
+	return "schedule"
+}
+
+// Approve denies calls during freezes, holidays and outside windows.
+func (a *Approver) Approve(_ context.Context, _ approver.Request) (approver.Decision, error) {
+	now := time.Now()
+	if a.Now != nil {
+		now = a.Now()
+	}
+	now = now.In(a.location())
+	freezes, holidays, err := a.periods()
+	if err != nil {
+		return approver.Decision{Allowed: false, Reason: err.Error(), Source: a.Name()}, nil
+	}
+	if period, ok := find(freezes, now); ok {
+		return a.deny("schedule.freeze", "Changes are frozen", period.Reason, freezes, holidays, now), nil
+	}
+	if period, ok := find(holidays, now); ok {
+		return a.deny("schedule.holiday", "Changes are not allowed on holidays", period.Reason, freezes, holidays, now), nil
+	}
+	if !a.inWindow(now) {
+		return a.deny("schedule.closed", "Changes are allowed only inside configured time windows", "", freezes, holidays, now), nil
+	}
+	return approver.Decision{Allowed: true, Reason: "approved", Source: a.Name()}, nil
+}
+
+func (a *Approver) deny(key, fallback, reason string, freezes, holidays []timewindow.Period, now time.Time) approver.Decision {
+	next := ""
+	if at, ok := a.next(now, freezes, holidays); ok {
+		next = at.Format(nextLayout)
+	}
+	message := fallback
+	if reason != "" {
+		message += ": " + reason
+	}
+	message += "."
+	if next != "" {
+		message += " The next window opens at " + next + "."
+	}
+	if a.Renderer != nil {
+		if rendered, err := a.Renderer.Render(key, map[string]any{"Reason": reason, "Next": next}); err == nil {
+			message = rendered
+		}
+	}
+	return approver.Decision{Allowed: false, Reason: message, Source: a.Name()}
+}
+
+func (a *Approver) location() *time.Location {
+	if a.Location != nil {
+		return a.Location
+	}
+	return time.UTC
+}
+
+func (a *Approver) periods() ([]timewindow.Period, []timewindow.Period, error) {
+	a.once.Do(func() {
+		if a.FreezeFile != "" {
+			a.freezeFile = &periodFile{path: a.FreezeFile, parse: parseFreezeFile}
+		}
+		if a.HolidaysFile != "" {
+			a.holidaysFile = &periodFile{path: a.HolidaysFile, parse: ParseICal}
+		}
+	})
+	freezes := a.Freezes
+	if a.freezeFile != nil {
+		loaded, err := a.freezeFile.get(a.location())
+		if err != nil {
+			return nil, nil, fmt.Errorf("freeze file: %w", err)
+		}
+		freezes = append(append([]timewindow.Period(nil), freezes...), loaded...)
+	}
+	var holidays []timewindow.Period
+	if a.holidaysFile != nil {
+		loaded, err := a.holidaysFile.get(a.location())
+		if err != nil {
+			return nil, nil, fmt.Errorf("holidays calendar: %w", err)
+		}
+		holidays = loaded
+	}
+	return freezes, holidays, nil
+}
+
+func (a *Approver) inWindow(t time.Time) bool {
+	if len(a.Windows) == 0 {
+		return true
+	}
+	offset := clock(t)
+	for _, window := range a.Windows {
+		if window.Matches(t.Weekday()) && offset >= window.From && offset < window.To {
+			return true
+		}
+	}
+	return false
+}
+
+// next returns the first instant at or after t that is inside a window and outside blocking periods.
+func (a *Approver) next(t time.Time, freezes, holidays []timewindow.Period) (time.Time, bool) {
+	for step := 0; step < maxSteps; step++ {
+		if period, ok := find(freezes, t); ok {
+			t = period.End.In(t.Location())
+			continue
+		}
+		if period, ok := find(holidays, t); ok {
+			t = period.End.In(t.Location())
+			continue
+		}
+		if a.inWindow(t) {
+			return t, true
+		}
+		start, ok := a.windowStart(t)
+		if !ok {
+			return time.Time{}, false
+		}
+		t = start
+	}
+	return time.Time{}, false
+}
+
+// windowStart returns the nearest window start after t.
+func (a *Approver) windowStart(t time.Time) (time.Time, bool) {
+	day := timewindow.StartOfDay(t)
+	for offset := 0; offset <= 7; offset++ {
+		date := day.AddDate(0, 0, offset)
+		var best time.Time
+		for _, window := range a.Windows {
+			if !window.Matches(date.Weekday()) {
+				continue
+			}
+			start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location()).Add(window.From)
+			if start.After(t) && (best.IsZero() || start.Before(best)) {
+				best = start
+			}
+		}
+		if !best.IsZero() {
+			return best, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func find(periods []timewindow.Period, t time.Time) (timewindow.Period, bool) {
+	for _, period := range periods {
+		if !t.Before(period.Start) && t.Before(period.End) {
+			return period, true
+		}
+	}
+	return timewindow.Period{}, false
+}
+
+func clock(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+}
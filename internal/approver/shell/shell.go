@@ -2,13 +2,18 @@ package shell
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"strings"
 
+	"github.com/codex-k8s/yaml-mcp-server/internal/constants"
 	"github.com/codex-k8s/yaml-mcp-server/internal/executil"
+	"github.com/codex-k8s/yaml-mcp-server/internal/protocol"
 	"github.com/codex-k8s/yaml-mcp-server/internal/runtime/approver"
+	"github.com/codex-k8s/yaml-mcp-server/internal/secrets"
 )
 
-// Approver runs a shell command and decides based on exit code.
+// Approver runs a shell command and decides based on exit code or, with Output json, on its stdout.
 type Approver struct {
 	// Label is a human-friendly name.
 	Label string
@@ -20,6 +25,12 @@ type Approver struct {
 	Env map[string]string
 	// AllowExitCodes declares additional success exit codes.
 	AllowExitCodes []int
+	// Output is text (default) or json.
+	Output string
+	// SecretEnv adds environment variables resolved from secrets at call time.
+	SecretEnv map[string]secrets.Ref
+	// Secrets resolves SecretEnv references.
+	Secrets *secrets.Store
 }
 
 // Name returns approver name for audit and logging.
@@ -32,22 +43,23 @@ func (a Approver) Name() string {
 
 // Approve executes the shell command and returns an approval decision.
 func (a Approver) Approve(ctx context.Context, req approver.Request) (approver.Decision, error) {
-	output, exitCode, err := executil.RunCommand(ctx, a.Command, a.Args, a.Env, executil.TemplateData{
+	secretEnv, err := a.Secrets.Resolve(ctx, a.SecretEnv)
+	if err != nil {
+		return approver.Decision{Allowed: false, Reason: "failed to resolve secrets", Source: a.Name()}, err
+	}
+	data := executil.TemplateData{
 		Args:          req.Arguments,
 		ToolName:      req.ToolName,
 		CorrelationID: req.CorrelationID,
-	})
-
-	allowed := err == nil
-	if !allowed && len(a.AllowExitCodes) > 0 {
-		for _, code := range a.AllowExitCodes {
-			if code == exitCode {
-				allowed = true
-				break
-			}
-		}
+		Diff:          req.Diff,
+		Metadata:      req.Metadata,
+	}
+	if strings.EqualFold(strings.TrimSpace(a.Output), constants.ShellOutputJSON) {
+		return a.approveJSON(ctx, secretEnv, data), nil
 	}
+	output, exitCode, err := executil.RunCommand(ctx, a.Command, a.Args, a.Env, secretEnv, data)
 
+	allowed := a.succeeded(exitCode, err)
 	reason := strings.TrimSpace(output)
 	if reason == "" {
 		if allowed {
@@ -59,3 +71,64 @@ func (a Approver) Approve(ctx context.Context, req approver.Request) (approver.D
 
 	return approver.Decision{Allowed: allowed, Reason: reason, Source: a.Name()}, nil
 }
+
+// jsonOutput is the stdout document expected with Output json.
+type jsonOutput struct {
+	Decision  string         `json:"decision"`
+	Reason    string         `json:"reason"`
+	Metadata  map[string]any `json:"metadata"`
+	Questions []string       `json:"questions"`
+}
+
+// approveJSON runs the command and decides from the JSON document on stdout; stderr is ignored.
+// An approve decision from a command that failed is treated as a denial.
+func (a Approver) approveJSON(ctx context.Context, secretEnv map[string]string, data executil.TemplateData) approver.Decision {
+	stdout, _, exitCode, err := executil.RunCommandSplit(ctx, a.Command, a.Args, a.Env, secretEnv, data)
+	if exitCode < 0 && err != nil {
+		return approver.Decision{Allowed: false, Reason: "shell approver failed: " + err.Error(), Source: a.Name()}
+	}
+
+	var parsed jsonOutput
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stdout)), &parsed); err != nil {
+		return approver.Decision{Allowed: false, Reason: "invalid shell approver output: " + err.Error(), Source: a.Name()}
+	}
+	reason := strings.TrimSpace(parsed.Reason)
+
+	var decision approver.Decision
+	switch strings.ToLower(strings.TrimSpace(parsed.Decision)) {
+	case protocol.DecisionApprove:
+		if !a.succeeded(exitCode, err) {
+			decision = approver.Decision{Allowed: false, Reason: fmt.Sprintf("approve decision with exit code %d", exitCode)}
+			break
+		}
+		if reason == "" {
+			reason = "approved"
+		}
+		decision = approver.Decision{Allowed: true, Reason: reason}
+	case protocol.DecisionDeny:
+		if reason == "" {
+			reason = "denied"
+		}
+		decision = approver.Decision{Allowed: false, Reason: reason}
+	case protocol.DecisionNeedsInfo:
+		decision = approver.NeedsInfo(reason, parsed.Questions, "")
+	default:
+		decision = approver.Decision{Allowed: false, Reason: fmt.Sprintf("invalid shell approver decision %q", parsed.Decision)}
+	}
+	decision.Source = a.Name()
+	decision.Metadata = parsed.Metadata
+	return decision
+}
+
+// succeeded reports whether the command exited successfully or with an allowed exit code.
+func (a Approver) succeeded(exitCode int, err error) bool {
+	if err == nil {
+		return true
+	}
+	for _, code := range a.AllowExitCodes {
+		if code == exitCode {
+			return true
+		}
+	}
+	return false
+}
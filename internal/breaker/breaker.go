@@ -0,0 +1,187 @@
+package breaker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Breaker states.
+const (
+	StateClosed   = "closed"
+	StateOpen     = "open"
+	StateHalfOpen = "half_open"
+)
+
+// Config controls breaker thresholds.
+type Config struct {
+	// FailureThreshold is the number of consecutive failures that opens the breaker.
+	FailureThreshold int
+	// OpenDuration is how long the breaker stays open before probing.
+	OpenDuration time.Duration
+	// HalfOpenProbes is the number of concurrent probe calls allowed while half-open.
+	HalfOpenProbes int
+}
+
+// Breaker tracks failures of a single endpoint.
+type Breaker struct {
+	name   string
+	cfg    Config
+	logger *slog.Logger
+
+	mu       sync.Mutex
+	state    string
+	failures int
+	openedAt time.Time
+	probes   int
+}
+
+func newBreaker(name string, cfg Config, logger *slog.Logger) *Breaker {
+	return &Breaker{name: name, cfg: cfg.withDefaults(), logger: logger, state: StateClosed}
+}
+
+// withDefaults fills unset thresholds.
+func (c Config) withDefaults() Config {
+	if c.FailureThreshold <= 0 {
+		c.FailureThreshold = 5
+	}
+	if c.OpenDuration <= 0 {
+		c.OpenDuration = 30 * time.Second
+	}
+	if c.HalfOpenProbes <= 0 {
+		c.HalfOpenProbes = 1
+	}
+	return c
+}
+
+// Allow reports whether a call may be sent now.
+func (b *Breaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case StateOpen:
+		if time.Since(b.openedAt) < b.cfg.OpenDuration {
+			return false
+		}
+		b.transition(StateHalfOpen)
+		b.probes = 0
+		fallthrough
+	case StateHalfOpen:
+		if b.probes >= b.cfg.HalfOpenProbes {
+			return false
+		}
+		b.probes++
+		return true
+	default:
+		return true
+	}
+}
+
+// Success records a successful call.
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	if b.state != StateClosed {
+		b.transition(StateClosed)
+	}
+}
+
+// Failure records a failed call.
+func (b *Breaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.state == StateHalfOpen || (b.state == StateClosed && b.failures >= b.cfg.FailureThreshold) {
+		b.openedAt = time.Now()
+		b.transition(StateOpen)
+	}
+}
+
+// Release frees a half-open probe slot when the call ended without a result.
+func (b *Breaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == StateHalfOpen && b.probes > 0 {
+		b.probes--
+	}
+}
+
+// State returns the current state.
+func (b *Breaker) State() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == StateOpen && time.Since(b.openedAt) >= b.cfg.OpenDuration {
+		return StateHalfOpen
+	}
+	return b.state
+}
+
+func (b *Breaker) transition(state string) {
+	if b.logger != nil {
+		level := slog.LevelInfo
+		if state == StateOpen {
+			level = slog.LevelWarn
+		}
+		b.logger.Log(context.Background(), level, "circuit breaker state changed", "endpoint", b.name, "from", b.state, "to", state, "failures", b.failures)
+	}
+	b.state = state
+}
+
+// Registry shares breakers between clients calling the same endpoint.
+type Registry struct {
+	logger   *slog.Logger
+	mu       sync.Mutex
+	breakers map[string]*Breaker
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry(logger *slog.Logger) *Registry {
+	return &Registry{logger: logger, breakers: map[string]*Breaker{}}
+}
+
+// Get returns the breaker for endpoint, creating it with cfg on first use.
+// A later cfg that differs from the registered one is rejected, since the breaker is shared.
+func (r *Registry) Get(endpoint string, cfg Config) (*Breaker, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if item, ok := r.breakers[endpoint]; ok {
+		if item.cfg != cfg.withDefaults() {
+			return nil, fmt.Errorf("circuit breaker for %s is already configured with different settings", endpoint)
+		}
+		return item, nil
+	}
+	item := newBreaker(endpoint, cfg, r.logger)
+	r.breakers[endpoint] = item
+	return item, nil
+}
+
+// Len returns the number of registered breakers.
+func (r *Registry) Len() int {
+	if r == nil {
+		return 0
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.breakers)
+}
+
+// Snapshot returns endpoint -> state for readiness reporting.
+func (r *Registry) Snapshot() map[string]string {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	items := make(map[string]*Breaker, len(r.breakers))
+	for endpoint, item := range r.breakers {
+		items[endpoint] = item
+	}
+	r.mu.Unlock()
+	out := make(map[string]string, len(items))
+	for endpoint, item := range items {
+		out[endpoint] = item.State()
+	}
+	return out
+}
@@ -0,0 +1,139 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/retry"
+)
+
+func TestBreakerTransitions(t *testing.T) {
+	tests := []struct {
+		name  string
+		cfg   Config
+		steps string // s = success, f = failure, w = wait past open duration, a = allowed, d = denied
+		want  string
+	}{
+		{name: "stays closed below threshold", cfg: Config{FailureThreshold: 3}, steps: "ffa", want: StateClosed},
+		{name: "opens at threshold", cfg: Config{FailureThreshold: 2}, steps: "ffd", want: StateOpen},
+		{name: "success resets failures", cfg: Config{FailureThreshold: 2}, steps: "fsfa", want: StateClosed},
+		{name: "half-open after open duration", cfg: Config{FailureThreshold: 1, OpenDuration: time.Millisecond}, steps: "fwa", want: StateHalfOpen},
+		{name: "probe success closes", cfg: Config{FailureThreshold: 1, OpenDuration: time.Millisecond}, steps: "fwasa", want: StateClosed},
+		{name: "probe slots are limited", cfg: Config{FailureThreshold: 1, OpenDuration: time.Millisecond, HalfOpenProbes: 1}, steps: "fwad", want: StateHalfOpen},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newBreaker("test", tt.cfg, nil)
+			for i, step := range tt.steps {
+				switch step {
+				case 's':
+					b.Success()
+				case 'f':
+					b.Failure()
+				case 'w':
+					time.Sleep(5 * time.Millisecond)
+				case 'a', 'd':
+					if got := b.Allow(); got != (step == 'a') {
+						t.Fatalf("step %d: Allow() = %v", i, got)
+					}
+				}
+			}
+			if got := b.State(); got != tt.want {
+				t.Fatalf("State() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBreakerHalfOpenFailureReopens(t *testing.T) {
+	b := newBreaker("test", Config{FailureThreshold: 1, OpenDuration: time.Millisecond}, nil)
+	b.Failure()
+	time.Sleep(5 * time.Millisecond)
+	if !b.Allow() {
+		t.Fatal("probe was not allowed")
+	}
+	b.cfg.OpenDuration = time.Hour
+	b.Failure()
+	if got := b.State(); got != StateOpen {
+		t.Fatalf("State() = %s, want %s", got, StateOpen)
+	}
+}
+
+func TestRegistryGet(t *testing.T) {
+	tests := []struct {
+		name    string
+		first   Config
+		second  Config
+		wantErr bool
+	}{
+		{name: "same config", first: Config{FailureThreshold: 3}, second: Config{FailureThreshold: 3}},
+		{name: "defaults match explicit values", first: Config{}, second: Config{FailureThreshold: 5, OpenDuration: 30 * time.Second, HalfOpenProbes: 1}},
+		{name: "conflicting config", first: Config{FailureThreshold: 3}, second: Config{FailureThreshold: 4}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry(nil)
+			first, err := registry.Get("https://a", tt.first)
+			if err != nil {
+				t.Fatal(err)
+			}
+			second, err := registry.Get("https://a", tt.second)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && first != second {
+				t.Fatal("Get() returned a different breaker for the same endpoint")
+			}
+		})
+	}
+}
+
+func TestEndpointsDo(t *testing.T) {
+	serverErr := &retry.StatusError{Code: http.StatusBadGateway}
+	clientErr := &retry.StatusError{Code: http.StatusBadRequest}
+	tests := []struct {
+		name    string
+		results map[string]error
+		open    []string
+		want    []string
+		wantErr error
+	}{
+		{name: "first endpoint answers", results: map[string]error{}, want: []string{"a"}},
+		{name: "fails over on server error", results: map[string]error{"a": serverErr}, want: []string{"a", "b"}},
+		{name: "client error does not fail over", results: map[string]error{"a": clientErr}, want: []string{"a"}, wantErr: clientErr},
+		{name: "skips open endpoint", results: map[string]error{}, open: []string{"a"}, want: []string{"b"}},
+		{name: "all open", results: map[string]error{}, open: []string{"a", "b"}, wantErr: ErrOpen},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry(nil)
+			endpoints, err := NewEndpoints(registry, Config{FailureThreshold: 1, OpenDuration: time.Hour}, "a", "b")
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, url := range tt.open {
+				item, _ := registry.Get(url, Config{FailureThreshold: 1, OpenDuration: time.Hour})
+				item.Failure()
+			}
+			var called []string
+			err = endpoints.Do(context.Background(), func(_ context.Context, url string) error {
+				called = append(called, url)
+				return tt.results[url]
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Do() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(called) != len(tt.want) {
+				t.Fatalf("called %v, want %v", called, tt.want)
+			}
+			for i := range called {
+				if called[i] != tt.want[i] {
+					t.Fatalf("called %v, want %v", called, tt.want)
+				}
+			}
+		})
+	}
+}
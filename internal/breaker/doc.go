@@ -0,0 +1,2 @@
+// Package breaker implements per-endpoint circuit breakers with ordered failover.
+package breaker
@@ -0,0 +1,96 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/retry"
+)
+
+// ErrOpen is returned when every endpoint has an open circuit.
+var ErrOpen = errors.New("circuit open")
+
+type endpoint struct {
+	url     string
+	breaker *Breaker
+}
+
+// Endpoints is an ordered list of URLs tried with failover.
+type Endpoints struct {
+	items []endpoint
+}
+
+// NewEndpoints binds urls (in failover order) to breakers from registry.
+func NewEndpoints(registry *Registry, cfg Config, urls ...string) (*Endpoints, error) {
+	out := &Endpoints{}
+	for _, raw := range urls {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		item := endpoint{url: raw}
+		if registry != nil {
+			var err error
+			if item.breaker, err = registry.Get(raw, cfg); err != nil {
+				return nil, err
+			}
+		}
+		out.items = append(out.items, item)
+	}
+	return out, nil
+}
+
+// Direct returns a single endpoint without a circuit breaker.
+func Direct(url string) *Endpoints {
+	return &Endpoints{items: []endpoint{{url: strings.TrimSpace(url)}}}
+}
+
+// Do calls fn for each endpoint in order until one does not fail.
+// Endpoints with an open circuit are skipped; when all are skipped ErrOpen is returned
+// without calling fn. Non-failure errors (e.g. 4xx responses) are returned as-is.
+func (e *Endpoints) Do(ctx context.Context, fn func(ctx context.Context, url string) error) error {
+	var (
+		lastErr error
+		skipped []string
+	)
+	for _, item := range e.items {
+		if item.breaker != nil && !item.breaker.Allow() {
+			skipped = append(skipped, item.url)
+			continue
+		}
+		err := fn(ctx, item.url)
+		if ctx.Err() != nil {
+			if item.breaker != nil {
+				item.breaker.Release()
+			}
+			return err
+		}
+		if err == nil || !isFailure(err) {
+			if item.breaker != nil {
+				item.breaker.Success()
+			}
+			return err
+		}
+		if item.breaker != nil {
+			item.breaker.Failure()
+		}
+		lastErr = err
+	}
+	if lastErr != nil {
+		return lastErr
+	}
+	return fmt.Errorf("%w for %s", ErrOpen, strings.Join(skipped, ", "))
+}
+
+func isFailure(err error) bool {
+	var statusErr *retry.StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Code >= http.StatusInternalServerError || statusErr.Code == http.StatusTooManyRequests
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
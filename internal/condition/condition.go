@@ -0,0 +1,97 @@
+package condition
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/cel-go/cel"
+)
+
+// Vars are the variables visible to expressions.
+type Vars struct {
+	// Args are the tool call arguments (`args`).
+	Args map[string]any
+	// Tool holds name, title, tags and metadata of the tool (`tool`).
+	Tool map[string]any
+	// Caller holds name, version and session_id of the MCP client (`caller`).
+	Caller map[string]any
+	// Now is the evaluation time (`now`, current time when zero).
+	Now time.Time
+}
+
+// Program is a compiled, type-checked boolean expression.
+type Program struct {
+	expression string
+	program    cel.Program
+}
+
+var env = func() *cel.Env {
+	mapType := cel.MapType(cel.StringType, cel.DynType)
+	created, err := cel.NewEnv(
+		cel.Variable("args", mapType),
+		cel.Variable("tool", mapType),
+		cel.Variable("caller", mapType),
+		cel.Variable("now", cel.TimestampType),
+		cel.CrossTypeNumericComparisons(true),
+	)
+	if err != nil {
+		panic(fmt.Sprintf("condition: cel env: %v", err))
+	}
+	return created
+}()
+
+// Compile parses and type-checks expression; it must evaluate to bool.
+func Compile(expression string) (*Program, error) {
+	expression = strings.TrimSpace(expression)
+	if expression == "" {
+		return nil, errors.New("expression is empty")
+	}
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if !ast.OutputType().IsExactType(cel.BoolType) {
+		return nil, fmt.Errorf("expression must return bool, got %s", ast.OutputType())
+	}
+	program, err := env.Program(ast)
+	if err != nil {
+		return nil, err
+	}
+	return &Program{expression: expression, program: program}, nil
+}
+
+// String returns the source expression.
+func (p *Program) String() string {
+	return p.expression
+}
+
+// Eval evaluates the expression; missing keys and type mismatches are returned as errors.
+func (p *Program) Eval(vars Vars) (bool, error) {
+	now := vars.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	out, _, err := p.program.Eval(map[string]any{
+		"args":   orEmpty(vars.Args),
+		"tool":   orEmpty(vars.Tool),
+		"caller": orEmpty(vars.Caller),
+		"now":    now,
+	})
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", p.expression, err)
+	}
+	value, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("%s: result is not bool", p.expression)
+	}
+	return value, nil
+}
+
+func orEmpty(value map[string]any) map[string]any {
+	if value == nil {
+		return map[string]any{}
+	}
+	return value
+}
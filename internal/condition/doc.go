@@ -0,0 +1,2 @@
+// Package condition compiles and evaluates CEL expressions over tool arguments, tool metadata, caller info and time.
+package condition
@@ -2,15 +2,72 @@ package constants
 
 // Executor type aliases.
 const (
-	ExecutorShell = "shell"
-	ExecutorHTTP  = "http"
+	ExecutorShell  = "shell"
+	ExecutorHTTP   = "http"
+	ExecutorFile   = "file"
+	ExecutorPlugin = "plugin"
+	ExecutorGRPC   = "grpc"
+)
+
+// File executor operations.
+const (
+	FileOpRead   = "read"
+	FileOpWrite  = "write"
+	FileOpAppend = "append"
+	FileOpList   = "list"
+	FileOpStat   = "stat"
+	FileOpDelete = "delete"
 )
 
 // Approver type aliases.
 const (
-	ApproverHTTP   = "http"
-	ApproverShell  = "shell"
-	ApproverLimits = "limits"
+	ApproverHTTP        = "http"
+	ApproverShell       = "shell"
+	ApproverLimits      = "limits"
+	ApproverPlugin      = "plugin"
+	ApproverGRPC        = "grpc"
+	ApproverAllOf       = "all_of"
+	ApproverAnyOf       = "any_of"
+	ApproverNOfM        = "n_of_m"
+	ApproverPolicy      = "policy"
+	ApproverSchedule    = "schedule"
+	ApproverElicitation = "elicitation"
+	ApproverSampling    = "sampling"
+)
+
+// Shell approver output modes.
+const (
+	ShellOutputText = "text"
+	ShellOutputJSON = "json"
+)
+
+// Policy rule effects.
+const (
+	PolicyAllow = "allow"
+	PolicyDeny  = "deny"
+)
+
+// Secret provider types.
+const (
+	SecretFile       = "file"
+	SecretEnv        = "env"
+	SecretKubernetes = "kubernetes"
+	SecretVault      = "vault"
+)
+
+// Tool async response modes.
+const (
+	AsyncResponseBlocking = "blocking"
+	AsyncResponseDeferred = "deferred"
+)
+
+// OperationStatusTool is the auto-registered tool that reports deferred operations.
+const OperationStatusTool = "get_operation_status"
+
+// Pending store types.
+const (
+	PendingStoreMemory = "memory"
+	PendingStoreBolt   = "bolt"
 )
 
 // Idempotency cache key strategies.
@@ -8,6 +8,10 @@ type Config struct {
 	Tools []ToolConfig `yaml:"tools"`
 	// Resources lists static resources.
 	Resources []ResourceConfig `yaml:"resources"`
+	// Secrets declares named secrets resolved at call time.
+	Secrets map[string]SecretConfig `yaml:"secrets"`
+	// HTTPClients declares named outbound HTTP client profiles.
+	HTTPClients map[string]HTTPClientConfig `yaml:"http_clients"`
 }
 
 // ServerConfig defines MCP server settings.
@@ -30,6 +34,18 @@ type ServerConfig struct {
 	ApprovalWebhookURL string `yaml:"approval_webhook_url"`
 	// ExecutorWebhookURL defines the callback URL for async executors.
 	ExecutorWebhookURL string `yaml:"executor_webhook_url"`
+	// PendingStore configures where pending async approvals and executions are kept.
+	PendingStore PendingStoreConfig `yaml:"pending_store"`
+}
+
+// PendingStoreConfig configures storage of pending async requests.
+type PendingStoreConfig struct {
+	// Type is memory or bolt (bolt when path is set, memory otherwise).
+	Type string `yaml:"type"`
+	// Path is the bbolt database file.
+	Path string `yaml:"path"`
+	// TTL is how long a sent request waits for its callback, even after the tool call timed out (default 24h).
+	TTL string `yaml:"ttl"`
 }
 
 // HTTPConfig configures the HTTP transport.
@@ -68,6 +84,10 @@ type ToolConfig struct {
 	Timeout string `yaml:"timeout"`
 	// TimeoutMessage is returned on timeout.
 	TimeoutMessage string `yaml:"timeout_message"`
+	// AsyncResponse is blocking (default) or deferred (return pending and finish in the background).
+	AsyncResponse string `yaml:"async_response"`
+	// AsyncNotify sends an MCP log notification when a deferred call finishes.
+	AsyncNotify bool `yaml:"async_notify"`
 	// InputSchema defines JSON Schema for tool input.
 	InputSchema map[string]any `yaml:"input_schema"`
 	// OutputSchema defines JSON Schema for tool output.
@@ -76,6 +96,8 @@ type ToolConfig struct {
 	Executor ExecutorConfig `yaml:"executor"`
 	// Approvers lists approval steps to run.
 	Approvers []ApproverConfig `yaml:"approvers"`
+	// ApprovalGrant lets identical calls reuse an approval for a limited time.
+	ApprovalGrant *ApprovalGrantConfig `yaml:"approval_grant,omitempty"`
 	// Metadata is an optional opaque map.
 	Metadata map[string]any `yaml:"metadata"`
 	// Tags is an optional list of tags.
@@ -106,6 +128,44 @@ type ExecutorConfig struct {
 	WebhookURL string `yaml:"webhook_url"`
 	// Spec carries declarative executor-specific settings.
 	Spec map[string]any `yaml:"spec"`
+	// Operation selects the file executor operation (read, write, append, list, stat, delete).
+	Operation string `yaml:"operation"`
+	// Path is the templated file path for file executor.
+	Path string `yaml:"path"`
+	// Content is the templated content for write and append operations.
+	Content string `yaml:"content"`
+	// Roots lists directories the file executor is confined to.
+	Roots []string `yaml:"roots"`
+	// MaxBytes limits file size for read, write and append operations.
+	MaxBytes int64 `yaml:"max_bytes"`
+	// ClientRoots additionally confines paths to roots advertised by the MCP client.
+	ClientRoots bool `yaml:"client_roots"`
+	// PingInterval controls plugin health ping frequency.
+	PingInterval string `yaml:"ping_interval"`
+	// Address is the gRPC executor target.
+	Address string `yaml:"address"`
+	// Stream uses the server-streaming gRPC call instead of the unary one.
+	Stream bool `yaml:"stream"`
+	// TLS configures gRPC transport security.
+	TLS *TLSConfig `yaml:"tls,omitempty"`
+	// SecretEnv injects secrets as environment variables (shell executor).
+	SecretEnv map[string]SecretRef `yaml:"secret_env"`
+	// ProgressPrefix marks shell output lines reported as progress (e.g. "::progress::").
+	ProgressPrefix string `yaml:"progress_prefix"`
+	// SecretHeaders injects secrets as headers (http and grpc executors).
+	SecretHeaders map[string]SecretRef `yaml:"secret_headers"`
+	// Retry retries failed executions within the tool timeout.
+	Retry *RetryConfig `yaml:"retry,omitempty"`
+	// URLs lists failover HTTP executor endpoints tried in order after URL.
+	URLs []string `yaml:"urls"`
+	// CircuitBreaker configures per-endpoint circuit breakers for HTTP executors.
+	CircuitBreaker *CircuitBreakerConfig `yaml:"circuit_breaker,omitempty"`
+	// HTTPClient references an http_clients profile (http executor).
+	HTTPClient string `yaml:"http_client"`
+	// WebhookAuth requires signed result callbacks (async http executor).
+	WebhookAuth *WebhookAuthConfig `yaml:"webhook_auth,omitempty"`
+	// CancelURL is notified when a call is cancelled or times out after the request was sent (http executor).
+	CancelURL string `yaml:"cancel_url"`
 }
 
 // HookConfig defines a startup hook command.
@@ -154,8 +214,216 @@ type ApproverConfig struct {
 	FieldPolicies map[string]FieldPolicy `yaml:"fields"`
 	// AllowExitCodes defines allowed shell exit codes.
 	AllowExitCodes []int `yaml:"allow_exit_codes"`
+	// Output selects how a shell approver's stdout is read: text (exit code decides) or json.
+	Output string `yaml:"output"`
 	// Payload is reserved for custom approvers.
 	Payload map[string]any `yaml:"payload"`
+	// PingInterval controls plugin health ping frequency.
+	PingInterval string `yaml:"ping_interval"`
+	// Address is the gRPC approver target.
+	Address string `yaml:"address"`
+	// Stream uses the server-streaming gRPC call instead of the unary one.
+	Stream bool `yaml:"stream"`
+	// TLS configures gRPC transport security.
+	TLS *TLSConfig `yaml:"tls,omitempty"`
+	// SecretEnv injects secrets as environment variables (shell approver).
+	SecretEnv map[string]SecretRef `yaml:"secret_env"`
+	// SecretHeaders injects secrets as headers (http and grpc approvers).
+	SecretHeaders map[string]SecretRef `yaml:"secret_headers"`
+	// Retry retries failed requests of http approvers.
+	Retry *RetryConfig `yaml:"retry,omitempty"`
+	// URLs lists failover HTTP approver endpoints tried in order after URL.
+	URLs []string `yaml:"urls"`
+	// CircuitBreaker configures per-endpoint circuit breakers for HTTP approvers.
+	CircuitBreaker *CircuitBreakerConfig `yaml:"circuit_breaker,omitempty"`
+	// HTTPClient references an http_clients profile (http approver).
+	HTTPClient string `yaml:"http_client"`
+	// WebhookAuth requires signed decision callbacks (async http approver).
+	WebhookAuth *WebhookAuthConfig `yaml:"webhook_auth,omitempty"`
+	// CancelURL is notified when a call is cancelled or times out after the request was sent (http approver).
+	CancelURL string `yaml:"cancel_url"`
+	// ResultCallbackURL receives the execution result after approval (http approver).
+	ResultCallbackURL string `yaml:"result_callback_url"`
+	// Approvers are the children of all_of, any_of and n_of_m groups, run concurrently.
+	Approvers []ApproverConfig `yaml:"approvers"`
+	// Quorum is the number of approvals required by n_of_m.
+	Quorum int `yaml:"quorum"`
+	// When is a CEL expression over args, tool and caller; the approver is skipped when it is false.
+	When string `yaml:"when"`
+	// Rules are the ordered rules of a policy approver; the first matching rule decides.
+	Rules []PolicyRuleConfig `yaml:"rules"`
+	// Default is the policy effect when no rule matches (allow/deny, default deny).
+	Default string `yaml:"default"`
+	// Timezone is the IANA time zone of schedule windows and dates (default UTC).
+	Timezone string `yaml:"timezone"`
+	// Windows are the weekly windows a schedule approver allows calls in.
+	Windows []ScheduleWindowConfig `yaml:"windows"`
+	// Freezes are change freeze periods of a schedule approver.
+	Freezes []FreezeConfig `yaml:"freezes"`
+	// FreezeFile is a YAML/JSON list of freeze periods re-read on change (schedule approver).
+	FreezeFile string `yaml:"freeze_file"`
+	// HolidaysICal is an iCal file with holidays re-read on change (schedule approver).
+	HolidaysICal string `yaml:"holidays_ical"`
+	// Escalation lists HTTP approvers the request is re-sent to when earlier ones do not answer (http approver).
+	Escalation []ApproverConfig `yaml:"escalation"`
+	// After is how long the previous escalation level waits before this one is asked (escalation entries).
+	After string `yaml:"after"`
+	// Fallback decides when the MCP client does not support elicitation or sampling (elicitation and sampling approvers).
+	Fallback *ApproverConfig `yaml:"fallback"`
+	// Prompt is the reviewer system prompt (sampling approver).
+	Prompt string `yaml:"prompt"`
+	// MaxTokens bounds the reviewer reply (sampling approver).
+	MaxTokens int `yaml:"max_tokens"`
+	// PatchableFields lists the top-level arguments a decision may patch (http and plugin approvers).
+	PatchableFields []string `yaml:"patchable_fields"`
+}
+
+// FreezeConfig defines a change freeze period.
+type FreezeConfig struct {
+	// From is a date (2006-01-02) or RFC3339 time.
+	From string `yaml:"from"`
+	// To is a date (inclusive) or RFC3339 time (exclusive); defaults to the end of From's day.
+	To string `yaml:"to"`
+	// Reason is shown to the model while the freeze is active.
+	Reason string `yaml:"reason"`
+}
+
+// ScheduleWindowConfig defines a weekly schedule window.
+type ScheduleWindowConfig struct {
+	// Days lists weekdays (mon..sun); every day when empty.
+	Days []string `yaml:"days"`
+	// From is the window start (HH:MM).
+	From string `yaml:"from"`
+	// To is the window end (HH:MM, exclusive; 24:00 ends the day).
+	To string `yaml:"to"`
+}
+
+// PolicyRuleConfig defines a single policy approver rule.
+type PolicyRuleConfig struct {
+	// Name identifies the rule in reasons and policy test output.
+	Name string `yaml:"name"`
+	// When is a CEL expression over args, tool, caller and now.
+	When string `yaml:"when"`
+	// Effect is allow or deny.
+	Effect string `yaml:"effect"`
+	// Reason is a template rendered with tool arguments ({{ .Args.<name> }}).
+	Reason string `yaml:"reason"`
+}
+
+// CircuitBreakerConfig configures a per-endpoint circuit breaker.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures that opens the circuit (default 5).
+	FailureThreshold int `yaml:"failure_threshold"`
+	// OpenDuration is how long the circuit stays open before probing (default 30s).
+	OpenDuration string `yaml:"open_duration"`
+	// HalfOpenProbes is the number of probe calls allowed while half-open (default 1).
+	HalfOpenProbes int `yaml:"half_open_probes"`
+}
+
+// RetryConfig configures retries with exponential backoff.
+type RetryConfig struct {
+	// MaxAttempts is the total number of attempts including the first one (default 3).
+	MaxAttempts int `yaml:"max_attempts"`
+	// InitialBackoff is the delay before the first retry (default 500ms).
+	InitialBackoff string `yaml:"initial_backoff"`
+	// MaxBackoff caps the delay between attempts (default 10s).
+	MaxBackoff string `yaml:"max_backoff"`
+	// Multiplier grows the delay after each attempt (default 2).
+	Multiplier float64 `yaml:"multiplier"`
+	// Jitter randomizes delays by +/- the given fraction (default 0.2).
+	Jitter *float64 `yaml:"jitter"`
+	// RetryOnStatus lists retryable HTTP status codes (default 429, 502, 503, 504).
+	RetryOnStatus []int `yaml:"retry_on_status"`
+	// RetryOnExitCodes lists retryable shell exit codes (shell commands are not retried without it).
+	RetryOnExitCodes []int `yaml:"retry_on_exit_codes"`
+}
+
+// SecretRef references a named secret from the secrets block.
+type SecretRef struct {
+	// SecretRef is the secret name.
+	SecretRef string `yaml:"secret_ref"`
+	// Prefix is prepended to the value (e.g. "Bearer ").
+	Prefix string `yaml:"prefix"`
+}
+
+// SecretConfig declares a secret provider entry.
+type SecretConfig struct {
+	// Type selects the provider (file, env, kubernetes, vault).
+	Type string `yaml:"type"`
+	// Path is the file path (file) or the API path after /v1/ (vault).
+	Path string `yaml:"path"`
+	// Name is the env variable (env) or the Secret name (kubernetes).
+	Name string `yaml:"name"`
+	// Namespace is the Secret namespace (kubernetes, pod namespace by default).
+	Namespace string `yaml:"namespace"`
+	// Key is the Secret data key (kubernetes) or the field name (vault).
+	Key string `yaml:"key"`
+	// Address is the Vault base URL.
+	Address string `yaml:"address"`
+	// TokenFile holds the Vault token.
+	TokenFile string `yaml:"token_file"`
+	// TokenEnv names the env variable with the Vault token (VAULT_TOKEN by default).
+	TokenEnv string `yaml:"token_env"`
+	// TLS configures the Vault client.
+	TLS *TLSConfig `yaml:"tls,omitempty"`
+	// TTL controls how long a resolved value is cached (default 5m).
+	TTL string `yaml:"ttl"`
+}
+
+// HTTPClientConfig declares an outbound HTTP client profile.
+type HTTPClientConfig struct {
+	// BearerTokenFile is sent as "Authorization: Bearer" and re-read when the file changes.
+	BearerTokenFile string `yaml:"bearer_token_file"`
+	// HMAC signs request bodies.
+	HMAC *HMACConfig `yaml:"hmac,omitempty"`
+	// TLS configures client certificates and CA bundles.
+	TLS *TLSConfig `yaml:"tls,omitempty"`
+	// Proxy is the proxy URL (HTTP_PROXY/HTTPS_PROXY env by default).
+	Proxy string `yaml:"proxy"`
+}
+
+// HMACConfig configures HMAC-SHA256 request signing.
+type HMACConfig struct {
+	// KeyFile holds the signing key.
+	KeyFile string `yaml:"key_file"`
+	// SecretRef names the signing key in secrets.
+	SecretRef string `yaml:"secret_ref"`
+	// SignatureHeader carries "sha256=<hex>" (X-YAML-MCP-Signature by default).
+	SignatureHeader string `yaml:"signature_header"`
+	// TimestampHeader carries the unix timestamp (X-YAML-MCP-Timestamp by default).
+	TimestampHeader string `yaml:"timestamp_header"`
+}
+
+// WebhookAuthConfig configures verification of async webhook callbacks.
+type WebhookAuthConfig struct {
+	// KeyFile holds the shared signing key.
+	KeyFile string `yaml:"key_file"`
+	// SecretRef names the shared signing key in secrets.
+	SecretRef string `yaml:"secret_ref"`
+	// SignatureHeader carries "sha256=<hex>" (X-YAML-MCP-Signature by default).
+	SignatureHeader string `yaml:"signature_header"`
+	// TimestampHeader carries the unix timestamp (X-YAML-MCP-Timestamp by default).
+	TimestampHeader string `yaml:"timestamp_header"`
+	// NonceHeader carries a unique value per callback (X-YAML-MCP-Nonce by default).
+	NonceHeader string `yaml:"nonce_header"`
+	// MaxAge bounds accepted timestamp skew (default 5m).
+	MaxAge string `yaml:"max_age"`
+}
+
+// TLSConfig configures client-side TLS for outbound connections.
+type TLSConfig struct {
+	// Disable turns TLS off (plaintext gRPC).
+	Disable bool `yaml:"disable"`
+	// CAFile is a PEM bundle with trusted CAs.
+	CAFile string `yaml:"ca_file"`
+	// CertFile is a PEM client certificate for mTLS.
+	CertFile string `yaml:"cert_file"`
+	// KeyFile is a PEM client key for mTLS.
+	KeyFile string `yaml:"key_file"`
+	// ServerName overrides the expected server name.
+	ServerName string `yaml:"server_name"`
+	// InsecureSkipVerify disables server certificate verification.
+	InsecureSkipVerify bool `yaml:"insecure_skip_verify"`
 }
 
 // FieldPolicy defines validation rules for tool input fields.
@@ -198,6 +466,17 @@ type IdempotencyConfig struct {
 	KeyStrategy string `yaml:"key_strategy"`
 }
 
+// ApprovalGrantConfig configures reusable approvals for repeated identical calls.
+type ApprovalGrantConfig struct {
+	// TTL controls how long a grant is valid.
+	TTL string `yaml:"ttl"`
+	// MaxUses limits how many calls may reuse a grant (unlimited when zero).
+	MaxUses int `yaml:"max_uses"`
+	// Fields lists the argument fields (dot paths) a grant is bound to; all arguments except
+	// justification, approval_request, risk_assessment and call metadata when empty.
+	Fields []string `yaml:"fields"`
+}
+
 // ToolAnnotationsConfig defines tool behavior hints.
 type ToolAnnotationsConfig struct {
 	// ReadOnlyHint indicates a read-only tool.
@@ -3,10 +3,15 @@ package dsl
 import (
 	"fmt"
 	"net/url"
+	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/codex-k8s/yaml-mcp-server/internal/condition"
 	"github.com/codex-k8s/yaml-mcp-server/internal/constants"
+	"github.com/codex-k8s/yaml-mcp-server/internal/executil"
+	"github.com/codex-k8s/yaml-mcp-server/internal/timewindow"
 )
 
 // Validate applies defaults and verifies required fields.
@@ -64,7 +69,30 @@ func Validate(cfg *Config) error {
 		}
 	}
 
+	names := make([]string, 0, len(cfg.Secrets))
+	for name := range cfg.Secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := validateSecret(cfg.Secrets[name]); err != nil {
+			return fmt.Errorf("secrets.%s.%w", name, err)
+		}
+	}
+
+	clientNames := make([]string, 0, len(cfg.HTTPClients))
+	for name := range cfg.HTTPClients {
+		clientNames = append(clientNames, name)
+	}
+	sort.Strings(clientNames)
+	for _, name := range clientNames {
+		if err := validateHTTPClient(cfg.HTTPClients[name], cfg.Secrets); err != nil {
+			return fmt.Errorf("http_clients.%s.%w", name, err)
+		}
+	}
+
 	toolNames := map[string]struct{}{}
+	deferred := false
 	for i, tool := range cfg.Tools {
 		if tool.Name == "" {
 			return fmt.Errorf("tools[%d].name is required", i)
@@ -73,17 +101,32 @@ func Validate(cfg *Config) error {
 			return fmt.Errorf("duplicate tool name: %s", tool.Name)
 		}
 		toolNames[tool.Name] = struct{}{}
+		switch strings.ToLower(strings.TrimSpace(tool.AsyncResponse)) {
+		case "", constants.AsyncResponseBlocking:
+			if tool.AsyncNotify {
+				return fmt.Errorf("tools[%d].async_notify requires async_response: deferred", i)
+			}
+		case constants.AsyncResponseDeferred:
+			deferred = true
+		default:
+			return fmt.Errorf("tools[%d].async_response must be blocking or deferred", i)
+		}
 		if strings.TrimSpace(tool.Executor.Type) == "" {
 			return fmt.Errorf("tools[%d].executor.type is required", i)
 		}
 		switch strings.ToLower(strings.TrimSpace(tool.Executor.Type)) {
 		case constants.ExecutorShell:
 		case constants.ExecutorHTTP:
-			if strings.TrimSpace(tool.Executor.URL) == "" {
+			if strings.TrimSpace(tool.Executor.URL) == "" && len(tool.Executor.URLs) == 0 {
 				return fmt.Errorf("tools[%d].executor.url is required for http executor", i)
 			}
-			if _, err := parseHTTPURL(tool.Executor.URL); err != nil {
-				return fmt.Errorf("tools[%d].executor.url is invalid: %w", i, err)
+			if strings.TrimSpace(tool.Executor.URL) != "" {
+				if _, err := parseHTTPURL(tool.Executor.URL); err != nil {
+					return fmt.Errorf("tools[%d].executor.url is invalid: %w", i, err)
+				}
+			}
+			if err := validateEndpoints(tool.Executor.URLs, tool.Executor.CircuitBreaker); err != nil {
+				return fmt.Errorf("tools[%d].executor.%w", i, err)
 			}
 			if strings.TrimSpace(tool.Executor.WebhookURL) != "" {
 				if _, err := parseWebhookURL(tool.Executor.WebhookURL); err != nil {
@@ -98,36 +141,57 @@ func Validate(cfg *Config) error {
 					return fmt.Errorf("async http executor requires http transport")
 				}
 			}
+		case constants.ExecutorPlugin:
+			if strings.TrimSpace(tool.Executor.Command) == "" {
+				return fmt.Errorf("tools[%d].executor.command is required for plugin executor", i)
+			}
+			if err := validateDuration(tool.Executor.PingInterval); err != nil {
+				return fmt.Errorf("tools[%d].executor.ping_interval is invalid: %w", i, err)
+			}
+		case constants.ExecutorGRPC:
+			if strings.TrimSpace(tool.Executor.Address) == "" {
+				return fmt.Errorf("tools[%d].executor.address is required for grpc executor", i)
+			}
+			if err := validateTLS(tool.Executor.TLS); err != nil {
+				return fmt.Errorf("tools[%d].executor.tls: %w", i, err)
+			}
+		case constants.ExecutorFile:
+			if err := validateFileExecutor(tool.Executor); err != nil {
+				return fmt.Errorf("tools[%d].executor.%w", i, err)
+			}
 		default:
 			return fmt.Errorf("tools[%d].executor.type is unsupported: %s", i, tool.Executor.Type)
 		}
+		if err := validateSecretUsage(cfg.Secrets, tool.Executor.Type, tool.Executor.SecretEnv, tool.Executor.SecretHeaders); err != nil {
+			return fmt.Errorf("tools[%d].executor.%w", i, err)
+		}
+		if strings.TrimSpace(tool.Executor.ProgressPrefix) != "" && !strings.EqualFold(tool.Executor.Type, constants.ExecutorShell) {
+			return fmt.Errorf("tools[%d].executor.progress_prefix is only supported for shell executor", i)
+		}
+		if err := validateRetry(tool.Executor.Retry); err != nil {
+			return fmt.Errorf("tools[%d].executor.retry.%w", i, err)
+		}
+		if err := validateHTTPClientRef(cfg.HTTPClients, tool.Executor.Type, tool.Executor.HTTPClient); err != nil {
+			return fmt.Errorf("tools[%d].executor.%w", i, err)
+		}
+		if err := validateWebhookAuth(tool.Executor.WebhookAuth, cfg.Secrets, tool.Executor.Type, tool.Executor.Async); err != nil {
+			return fmt.Errorf("tools[%d].executor.%w", i, err)
+		}
+		if err := validateHTTPURLField("cancel_url", tool.Executor.Type, tool.Executor.CancelURL); err != nil {
+			return fmt.Errorf("tools[%d].executor.%w", i, err)
+		}
 		for j, approver := range tool.Approvers {
-			if strings.TrimSpace(approver.Type) == "" {
-				return fmt.Errorf("tools[%d].approvers[%d].type is required", i, j)
-			}
-			if strings.EqualFold(approver.Type, constants.ApproverHTTP) {
-				if strings.TrimSpace(approver.Markup) != "" {
-					switch strings.ToLower(strings.TrimSpace(approver.Markup)) {
-					case "markdown", "html":
-					default:
-						return fmt.Errorf("tools[%d].approvers[%d].markup must be markdown or html", i, j)
-					}
-				}
-				if strings.TrimSpace(approver.WebhookURL) != "" {
-					if _, err := parseWebhookURL(approver.WebhookURL); err != nil {
-						return fmt.Errorf("tools[%d].approvers[%d].webhook_url is invalid: %w", i, j, err)
-					}
-				}
-				if approver.Async {
-					if strings.TrimSpace(cfg.Server.ApprovalWebhookURL) == "" && strings.TrimSpace(approver.WebhookURL) == "" {
-						return fmt.Errorf("async http approver requires server.approval_webhook_url or approver.webhook_url")
-					}
-					if strings.EqualFold(cfg.Server.Transport, "stdio") {
-						return fmt.Errorf("async http approver requires http transport")
-					}
-				}
+			if err := validateApprover(cfg, approver); err != nil {
+				return fmt.Errorf("tools[%d].approvers[%d].%w", i, j, err)
 			}
 		}
+		if err := validateApprovalGrant(tool); err != nil {
+			return fmt.Errorf("tools[%d].approval_grant.%w", i, err)
+		}
+	}
+
+	if _, exists := toolNames[constants.OperationStatusTool]; exists && deferred {
+		return fmt.Errorf("tool name %s is reserved when async_response: deferred is used", constants.OperationStatusTool)
 	}
 
 	if strings.TrimSpace(cfg.Server.ApprovalWebhookURL) != "" {
@@ -141,6 +205,25 @@ func Validate(cfg *Config) error {
 		}
 	}
 
+	if strings.TrimSpace(cfg.Server.PendingStore.Type) == "" {
+		cfg.Server.PendingStore.Type = constants.PendingStoreMemory
+		if strings.TrimSpace(cfg.Server.PendingStore.Path) != "" {
+			cfg.Server.PendingStore.Type = constants.PendingStoreBolt
+		}
+	}
+	switch strings.ToLower(strings.TrimSpace(cfg.Server.PendingStore.Type)) {
+	case constants.PendingStoreMemory:
+	case constants.PendingStoreBolt:
+		if strings.TrimSpace(cfg.Server.PendingStore.Path) == "" {
+			return fmt.Errorf("server.pending_store.path is required for bolt")
+		}
+	default:
+		return fmt.Errorf("server.pending_store.type must be memory or bolt")
+	}
+	if err := validateDuration(cfg.Server.PendingStore.TTL); err != nil {
+		return fmt.Errorf("server.pending_store.ttl is invalid: %w", err)
+	}
+
 	resourceURIs := map[string]struct{}{}
 	for i, res := range cfg.Resources {
 		if res.URI == "" {
@@ -179,3 +262,524 @@ func parseHTTPURL(raw string) (*url.URL, error) {
 	}
 	return parsed, nil
 }
+
+func validateFileExecutor(cfg ExecutorConfig) error {
+	switch strings.ToLower(strings.TrimSpace(cfg.Operation)) {
+	case constants.FileOpRead, constants.FileOpWrite, constants.FileOpAppend,
+		constants.FileOpList, constants.FileOpStat, constants.FileOpDelete:
+	case "":
+		return fmt.Errorf("operation is required for file executor")
+	default:
+		return fmt.Errorf("operation is unsupported: %s", cfg.Operation)
+	}
+	if strings.TrimSpace(cfg.Path) == "" {
+		return fmt.Errorf("path is required for file executor")
+	}
+	if len(cfg.Roots) == 0 {
+		return fmt.Errorf("roots are required for file executor")
+	}
+	for j, root := range cfg.Roots {
+		if !filepath.IsAbs(strings.TrimSpace(root)) {
+			return fmt.Errorf("roots[%d] must be an absolute path", j)
+		}
+	}
+	if cfg.MaxBytes < 0 {
+		return fmt.Errorf("max_bytes must be >= 0")
+	}
+	return nil
+}
+
+func validateDuration(raw string) error {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+	value, err := time.ParseDuration(strings.TrimSpace(raw))
+	if err != nil {
+		return err
+	}
+	if value <= 0 {
+		return fmt.Errorf("must be positive")
+	}
+	return nil
+}
+
+func validateTLS(cfg *TLSConfig) error {
+	if cfg == nil {
+		return nil
+	}
+	if (strings.TrimSpace(cfg.CertFile) == "") != (strings.TrimSpace(cfg.KeyFile) == "") {
+		return fmt.Errorf("cert_file and key_file must be set together")
+	}
+	return nil
+}
+
+func validateSecret(secret SecretConfig) error {
+	switch strings.ToLower(strings.TrimSpace(secret.Type)) {
+	case constants.SecretFile:
+		if strings.TrimSpace(secret.Path) == "" {
+			return fmt.Errorf("path is required for file secret")
+		}
+	case constants.SecretEnv:
+		if strings.TrimSpace(secret.Name) == "" {
+			return fmt.Errorf("name is required for env secret")
+		}
+	case constants.SecretKubernetes:
+		if strings.TrimSpace(secret.Name) == "" || strings.TrimSpace(secret.Key) == "" {
+			return fmt.Errorf("name and key are required for kubernetes secret")
+		}
+	case constants.SecretVault:
+		if _, err := parseHTTPURL(secret.Address); err != nil {
+			return fmt.Errorf("address is invalid: %w", err)
+		}
+		if strings.TrimSpace(secret.Path) == "" || strings.TrimSpace(secret.Key) == "" {
+			return fmt.Errorf("path and key are required for vault secret")
+		}
+		if err := validateTLS(secret.TLS); err != nil {
+			return fmt.Errorf("tls: %w", err)
+		}
+	case "":
+		return fmt.Errorf("type is required")
+	default:
+		return fmt.Errorf("type is unsupported: %s", secret.Type)
+	}
+	if err := validateDuration(secret.TTL); err != nil {
+		return fmt.Errorf("ttl is invalid: %w", err)
+	}
+	return nil
+}
+
+func validateSecretUsage(secrets map[string]SecretConfig, kind string, env, headers map[string]SecretRef) error {
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	if len(env) > 0 && kind != constants.ExecutorShell {
+		return fmt.Errorf("secret_env is only supported for shell")
+	}
+	if len(headers) > 0 && kind != constants.ExecutorHTTP && kind != constants.ExecutorGRPC {
+		return fmt.Errorf("secret_headers is only supported for http and grpc")
+	}
+	for field, refs := range map[string]map[string]SecretRef{"secret_env": env, "secret_headers": headers} {
+		for key, ref := range refs {
+			name := strings.TrimSpace(ref.SecretRef)
+			if name == "" {
+				return fmt.Errorf("%s.%s.secret_ref is required", field, key)
+			}
+			if _, ok := secrets[name]; !ok {
+				return fmt.Errorf("%s.%s references unknown secret: %s", field, key, name)
+			}
+		}
+	}
+	return nil
+}
+
+func validateRetry(cfg *RetryConfig) error {
+	if cfg == nil {
+		return nil
+	}
+	if cfg.MaxAttempts < 0 {
+		return fmt.Errorf("max_attempts must be >= 0")
+	}
+	if err := validateDuration(cfg.InitialBackoff); err != nil {
+		return fmt.Errorf("initial_backoff is invalid: %w", err)
+	}
+	if err := validateDuration(cfg.MaxBackoff); err != nil {
+		return fmt.Errorf("max_backoff is invalid: %w", err)
+	}
+	if cfg.Multiplier != 0 && cfg.Multiplier < 1 {
+		return fmt.Errorf("multiplier must be >= 1")
+	}
+	if cfg.Jitter != nil && (*cfg.Jitter < 0 || *cfg.Jitter > 1) {
+		return fmt.Errorf("jitter must be between 0 and 1")
+	}
+	for _, code := range cfg.RetryOnStatus {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("retry_on_status contains invalid status code: %d", code)
+		}
+	}
+	return nil
+}
+
+func validateEndpoints(urls []string, breaker *CircuitBreakerConfig) error {
+	for k, raw := range urls {
+		if _, err := parseHTTPURL(raw); err != nil {
+			return fmt.Errorf("urls[%d] is invalid: %w", k, err)
+		}
+	}
+	if breaker == nil {
+		return nil
+	}
+	if breaker.FailureThreshold < 0 {
+		return fmt.Errorf("circuit_breaker.failure_threshold must be >= 0")
+	}
+	if breaker.HalfOpenProbes < 0 {
+		return fmt.Errorf("circuit_breaker.half_open_probes must be >= 0")
+	}
+	if err := validateDuration(breaker.OpenDuration); err != nil {
+		return fmt.Errorf("circuit_breaker.open_duration is invalid: %w", err)
+	}
+	return nil
+}
+
+func validateHTTPClient(client HTTPClientConfig, secrets map[string]SecretConfig) error {
+	if strings.TrimSpace(client.Proxy) != "" {
+		if _, err := parseHTTPURL(client.Proxy); err != nil {
+			return fmt.Errorf("proxy is invalid: %w", err)
+		}
+	}
+	if err := validateTLS(client.TLS); err != nil {
+		return fmt.Errorf("tls: %w", err)
+	}
+	if client.TLS != nil && client.TLS.Disable {
+		return fmt.Errorf("tls.disable is not supported for http clients")
+	}
+	if client.HMAC == nil {
+		return nil
+	}
+	keyFile, secretRef := strings.TrimSpace(client.HMAC.KeyFile), strings.TrimSpace(client.HMAC.SecretRef)
+	if (keyFile == "") == (secretRef == "") {
+		return fmt.Errorf("hmac requires exactly one of key_file or secret_ref")
+	}
+	if secretRef != "" {
+		if _, ok := secrets[secretRef]; !ok {
+			return fmt.Errorf("hmac.secret_ref references unknown secret: %s", secretRef)
+		}
+	}
+	return nil
+}
+
+func validateHTTPClientRef(clients map[string]HTTPClientConfig, kind, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil
+	}
+	if !strings.EqualFold(strings.TrimSpace(kind), constants.ExecutorHTTP) {
+		return fmt.Errorf("http_client is only supported for http")
+	}
+	if _, ok := clients[name]; !ok {
+		return fmt.Errorf("http_client references unknown profile: %s", name)
+	}
+	return nil
+}
+
+func validateApprover(cfg *Config, approver ApproverConfig) error {
+	if strings.TrimSpace(approver.Type) == "" {
+		return fmt.Errorf("type is required")
+	}
+	if err := validateSecretUsage(cfg.Secrets, approver.Type, approver.SecretEnv, approver.SecretHeaders); err != nil {
+		return err
+	}
+	if approver.Retry != nil && !strings.EqualFold(approver.Type, constants.ApproverHTTP) {
+		return fmt.Errorf("retry is only supported for http approvers")
+	}
+	if err := validateRetry(approver.Retry); err != nil {
+		return fmt.Errorf("retry.%w", err)
+	}
+	if err := validateHTTPClientRef(cfg.HTTPClients, approver.Type, approver.HTTPClient); err != nil {
+		return err
+	}
+	if err := validateWebhookAuth(approver.WebhookAuth, cfg.Secrets, approver.Type, approver.Async); err != nil {
+		return err
+	}
+	if err := validateHTTPURLField("cancel_url", approver.Type, approver.CancelURL); err != nil {
+		return err
+	}
+	if err := validateHTTPURLField("result_callback_url", approver.Type, approver.ResultCallbackURL); err != nil {
+		return err
+	}
+	if strings.TrimSpace(approver.When) != "" {
+		if _, err := condition.Compile(approver.When); err != nil {
+			return fmt.Errorf("when is invalid: %w", err)
+		}
+	}
+	if err := validateApproverGroup(cfg, approver); err != nil {
+		return err
+	}
+	if err := validatePolicy(approver); err != nil {
+		return err
+	}
+	if err := validateSchedule(approver); err != nil {
+		return err
+	}
+	if err := validateEscalation(cfg, approver); err != nil {
+		return err
+	}
+	if err := validateFallback(cfg, approver); err != nil {
+		return err
+	}
+	if len(approver.PatchableFields) > 0 {
+		switch strings.ToLower(strings.TrimSpace(approver.Type)) {
+		case constants.ApproverHTTP, constants.ApproverPlugin:
+		default:
+			return fmt.Errorf("patchable_fields is only supported for http and plugin approvers")
+		}
+		for k, field := range approver.PatchableFields {
+			if strings.TrimSpace(field) == "" {
+				return fmt.Errorf("patchable_fields[%d] is empty", k)
+			}
+		}
+	}
+	if output := strings.TrimSpace(approver.Output); output != "" {
+		if !strings.EqualFold(approver.Type, constants.ApproverShell) {
+			return fmt.Errorf("output is only supported for shell approvers")
+		}
+		switch strings.ToLower(output) {
+		case constants.ShellOutputText, constants.ShellOutputJSON:
+		default:
+			return fmt.Errorf("output must be %s or %s", constants.ShellOutputText, constants.ShellOutputJSON)
+		}
+	}
+	if strings.EqualFold(approver.Type, constants.ApproverPlugin) {
+		if strings.TrimSpace(approver.Command) == "" {
+			return fmt.Errorf("command is required for plugin approver")
+		}
+		if err := validateDuration(approver.PingInterval); err != nil {
+			return fmt.Errorf("ping_interval is invalid: %w", err)
+		}
+	}
+	if strings.EqualFold(approver.Type, constants.ApproverGRPC) {
+		if strings.TrimSpace(approver.Address) == "" {
+			return fmt.Errorf("address is required for grpc approver")
+		}
+		if err := validateTLS(approver.TLS); err != nil {
+			return fmt.Errorf("tls: %w", err)
+		}
+	}
+	if strings.EqualFold(approver.Type, constants.ApproverHTTP) {
+		if err := validateEndpoints(approver.URLs, approver.CircuitBreaker); err != nil {
+			return err
+		}
+		if strings.TrimSpace(approver.Markup) != "" {
+			switch strings.ToLower(strings.TrimSpace(approver.Markup)) {
+			case "markdown", "html":
+			default:
+				return fmt.Errorf("markup must be markdown or html")
+			}
+		}
+		if strings.TrimSpace(approver.WebhookURL) != "" {
+			if _, err := parseWebhookURL(approver.WebhookURL); err != nil {
+				return fmt.Errorf("webhook_url is invalid: %w", err)
+			}
+		}
+		if approver.Async {
+			if strings.TrimSpace(cfg.Server.ApprovalWebhookURL) == "" && strings.TrimSpace(approver.WebhookURL) == "" {
+				return fmt.Errorf("async http approver requires server.approval_webhook_url or approver.webhook_url")
+			}
+			if strings.EqualFold(cfg.Server.Transport, "stdio") {
+				return fmt.Errorf("async http approver requires http transport")
+			}
+		}
+	}
+	return nil
+}
+
+func validatePolicy(approver ApproverConfig) error {
+	if !strings.EqualFold(strings.TrimSpace(approver.Type), constants.ApproverPolicy) {
+		if len(approver.Rules) > 0 || strings.TrimSpace(approver.Default) != "" {
+			return fmt.Errorf("rules and default are only supported for policy approver")
+		}
+		return nil
+	}
+	if len(approver.Rules) == 0 {
+		return fmt.Errorf("rules are required for policy approver")
+	}
+	if err := validatePolicyEffect(approver.Default, true); err != nil {
+		return fmt.Errorf("default %w", err)
+	}
+	for k, rule := range approver.Rules {
+		if _, err := condition.Compile(rule.When); err != nil {
+			return fmt.Errorf("rules[%d].when is invalid: %w", k, err)
+		}
+		if err := validatePolicyEffect(rule.Effect, false); err != nil {
+			return fmt.Errorf("rules[%d].effect %w", k, err)
+		}
+		if err := executil.CheckTemplate(rule.Reason); err != nil {
+			return fmt.Errorf("rules[%d].reason is invalid: %w", k, err)
+		}
+	}
+	return nil
+}
+
+func validateSchedule(approver ApproverConfig) error {
+	if !strings.EqualFold(strings.TrimSpace(approver.Type), constants.ApproverSchedule) {
+		if strings.TrimSpace(approver.Timezone) != "" || len(approver.Windows) > 0 || len(approver.Freezes) > 0 ||
+			strings.TrimSpace(approver.FreezeFile) != "" || strings.TrimSpace(approver.HolidaysICal) != "" {
+			return fmt.Errorf("timezone, windows, freezes, freeze_file and holidays_ical are only supported for schedule approver")
+		}
+		return nil
+	}
+	if len(approver.Windows) == 0 && len(approver.Freezes) == 0 &&
+		strings.TrimSpace(approver.FreezeFile) == "" && strings.TrimSpace(approver.HolidaysICal) == "" {
+		return fmt.Errorf("schedule approver requires windows, freezes, freeze_file or holidays_ical")
+	}
+	loc, err := time.LoadLocation(strings.TrimSpace(approver.Timezone))
+	if err != nil {
+		return fmt.Errorf("timezone is invalid: %w", err)
+	}
+	for k, window := range approver.Windows {
+		if _, err := timewindow.ParseWindow(window.Days, window.From, window.To); err != nil {
+			return fmt.Errorf("windows[%d].%w", k, err)
+		}
+	}
+	for k, freeze := range approver.Freezes {
+		if _, err := timewindow.ParsePeriod(timewindow.PeriodSpec(freeze), loc); err != nil {
+			return fmt.Errorf("freezes[%d].%w", k, err)
+		}
+	}
+	return nil
+}
+
+func validatePolicyEffect(value string, optional bool) error {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case constants.PolicyAllow, constants.PolicyDeny:
+		return nil
+	case "":
+		if optional {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be allow or deny")
+}
+
+func validateApproverGroup(cfg *Config, approver ApproverConfig) error {
+	kind := strings.ToLower(strings.TrimSpace(approver.Type))
+	switch kind {
+	case constants.ApproverAllOf, constants.ApproverAnyOf, constants.ApproverNOfM:
+	default:
+		if len(approver.Approvers) > 0 {
+			return fmt.Errorf("approvers are only supported for all_of, any_of and n_of_m")
+		}
+		if approver.Quorum != 0 {
+			return fmt.Errorf("quorum is only supported for n_of_m")
+		}
+		return nil
+	}
+	if len(approver.Approvers) == 0 {
+		return fmt.Errorf("approvers are required for %s", kind)
+	}
+	if kind == constants.ApproverNOfM {
+		if approver.Quorum < 1 || approver.Quorum > len(approver.Approvers) {
+			return fmt.Errorf("quorum must be between 1 and %d", len(approver.Approvers))
+		}
+	} else if approver.Quorum != 0 {
+		return fmt.Errorf("quorum is only supported for n_of_m")
+	}
+	for k, child := range approver.Approvers {
+		if err := validateApprover(cfg, child); err != nil {
+			return fmt.Errorf("approvers[%d].%w", k, err)
+		}
+	}
+	return nil
+}
+
+func validateApprovalGrant(tool ToolConfig) error {
+	grant := tool.ApprovalGrant
+	if grant == nil {
+		return nil
+	}
+	if len(tool.Approvers) == 0 {
+		return fmt.Errorf("approvers are required")
+	}
+	if strings.TrimSpace(grant.TTL) == "" {
+		return fmt.Errorf("ttl is required")
+	}
+	if err := validateDuration(grant.TTL); err != nil {
+		return fmt.Errorf("ttl is invalid: %w", err)
+	}
+	if grant.MaxUses < 0 {
+		return fmt.Errorf("max_uses must be non-negative")
+	}
+	for k, field := range grant.Fields {
+		if strings.TrimSpace(field) == "" {
+			return fmt.Errorf("fields[%d] is empty", k)
+		}
+	}
+	return nil
+}
+
+func validateEscalation(cfg *Config, approver ApproverConfig) error {
+	if strings.TrimSpace(approver.After) != "" {
+		return fmt.Errorf("after is only supported for escalation entries")
+	}
+	if len(approver.Escalation) == 0 {
+		return nil
+	}
+	if !strings.EqualFold(strings.TrimSpace(approver.Type), constants.ApproverHTTP) {
+		return fmt.Errorf("escalation is only supported for http approvers")
+	}
+	for k, entry := range approver.Escalation {
+		if !strings.EqualFold(strings.TrimSpace(entry.Type), constants.ApproverHTTP) {
+			return fmt.Errorf("escalation[%d].type must be http", k)
+		}
+		if strings.TrimSpace(entry.After) == "" {
+			return fmt.Errorf("escalation[%d].after is required", k)
+		}
+		if err := validateDuration(entry.After); err != nil {
+			return fmt.Errorf("escalation[%d].after is invalid: %w", k, err)
+		}
+		if len(entry.Escalation) > 0 {
+			return fmt.Errorf("escalation[%d].escalation cannot be nested", k)
+		}
+		entry.After = ""
+		if err := validateApprover(cfg, entry); err != nil {
+			return fmt.Errorf("escalation[%d].%w", k, err)
+		}
+	}
+	return nil
+}
+
+func validateFallback(cfg *Config, approver ApproverConfig) error {
+	kind := strings.ToLower(strings.TrimSpace(approver.Type))
+	if kind != constants.ApproverSampling && (strings.TrimSpace(approver.Prompt) != "" || approver.MaxTokens != 0) {
+		return fmt.Errorf("prompt and max_tokens are only supported for sampling approvers")
+	}
+	if approver.MaxTokens < 0 {
+		return fmt.Errorf("max_tokens must be >= 0")
+	}
+	if approver.Fallback == nil {
+		return nil
+	}
+	if kind != constants.ApproverElicitation && kind != constants.ApproverSampling {
+		return fmt.Errorf("fallback is only supported for elicitation and sampling approvers")
+	}
+	if strings.EqualFold(strings.TrimSpace(approver.Fallback.Type), kind) {
+		return fmt.Errorf("fallback.type cannot be %s", kind)
+	}
+	if err := validateApprover(cfg, *approver.Fallback); err != nil {
+		return fmt.Errorf("fallback.%w", err)
+	}
+	return nil
+}
+
+func validateHTTPURLField(field, kind, value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	if !strings.EqualFold(strings.TrimSpace(kind), constants.ExecutorHTTP) {
+		return fmt.Errorf("%s is only supported for http", field)
+	}
+	if _, err := parseHTTPURL(value); err != nil {
+		return fmt.Errorf("%s is invalid: %w", field, err)
+	}
+	return nil
+}
+
+func validateWebhookAuth(auth *WebhookAuthConfig, secrets map[string]SecretConfig, kind string, async bool) error {
+	if auth == nil {
+		return nil
+	}
+	if !strings.EqualFold(strings.TrimSpace(kind), constants.ExecutorHTTP) || !async {
+		return fmt.Errorf("webhook_auth is only supported for async http")
+	}
+	keyFile, secretRef := strings.TrimSpace(auth.KeyFile), strings.TrimSpace(auth.SecretRef)
+	if (keyFile == "") == (secretRef == "") {
+		return fmt.Errorf("webhook_auth requires exactly one of key_file or secret_ref")
+	}
+	if secretRef != "" {
+		if _, ok := secrets[secretRef]; !ok {
+			return fmt.Errorf("webhook_auth.secret_ref references unknown secret: %s", secretRef)
+		}
+	}
+	if err := validateDuration(auth.MaxAge); err != nil {
+		return fmt.Errorf("webhook_auth.max_age is invalid: %w", err)
+	}
+	return nil
+}
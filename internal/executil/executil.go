@@ -6,9 +6,17 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
 	"text/template"
 )
 
+// inheritedEnv lists the server variables passed to child processes; anything else, including
+// variables that back secrets, reaches a child only through env or secret_env.
+var inheritedEnv = []string{
+	"PATH", "HOME", "USER", "LOGNAME", "SHELL", "TERM", "TMPDIR", "TZ", "LANG", "LC_ALL", "LC_CTYPE",
+	"KUBERNETES_SERVICE_HOST", "KUBERNETES_SERVICE_PORT",
+}
+
 // TemplateData defines the available fields in command templates.
 type TemplateData struct {
 	// Args are tool arguments.
@@ -17,6 +25,10 @@ type TemplateData struct {
 	ToolName string
 	// CorrelationID links related operations.
 	CorrelationID string
+	// Diff is an optional change preview available to approvers.
+	Diff string
+	// Metadata is returned by earlier approvers (shell approvers with output: json).
+	Metadata map[string]any
 }
 
 // RenderTemplate renders a string template with TemplateData.
@@ -39,8 +51,17 @@ func RenderTemplate(value string, data TemplateData) (string, error) {
 	return buf.String(), nil
 }
 
+// CheckTemplate parses a template without rendering it.
+func CheckTemplate(value string) error {
+	_, err := template.New("value").Funcs(template.FuncMap{
+		"arg": func(string) any { return nil },
+	}).Parse(value)
+	return err
+}
+
 // BuildCommand builds an exec.Cmd with rendered command, args and env.
-func BuildCommand(ctx context.Context, command string, args []string, env map[string]string, data TemplateData) (*exec.Cmd, error) {
+// secretEnv values are appended as-is and never pass through templates.
+func BuildCommand(ctx context.Context, command string, args []string, env, secretEnv map[string]string, data TemplateData) (*exec.Cmd, error) {
 	renderedCommand, err := RenderTemplate(command, data)
 	if err != nil {
 		return nil, err
@@ -62,7 +83,7 @@ func BuildCommand(ctx context.Context, command string, args []string, env map[st
 		cmd = exec.CommandContext(ctx, renderedCommand, renderedArgs...)
 	}
 
-	cmd.Env = os.Environ()
+	cmd.Env = InheritedEnv()
 	for key, value := range env {
 		rendered, err := RenderTemplate(value, data)
 		if err != nil {
@@ -70,24 +91,101 @@ func BuildCommand(ctx context.Context, command string, args []string, env map[st
 		}
 		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", key, rendered))
 	}
+	for key, value := range secretEnv {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", key, value))
+	}
 
 	return cmd, nil
 }
 
+// InheritedEnv returns the allow-listed part of the server environment.
+func InheritedEnv() []string {
+	env := make([]string, 0, len(inheritedEnv))
+	for _, key := range inheritedEnv {
+		if value, ok := os.LookupEnv(key); ok {
+			env = append(env, key+"="+value)
+		}
+	}
+	return env
+}
+
 // RunCommand executes a command and returns output, exit code, and error.
-func RunCommand(ctx context.Context, command string, args []string, env map[string]string, data TemplateData) (string, int, error) {
-	cmd, err := BuildCommand(ctx, command, args, env, data)
+func RunCommand(ctx context.Context, command string, args []string, env, secretEnv map[string]string, data TemplateData) (string, int, error) {
+	return RunCommandLines(ctx, command, args, env, secretEnv, data, nil)
+}
+
+// RunCommandLines executes a command like RunCommand and passes every output line to onLine as it arrives.
+// Lines for which onLine returns true are left out of the returned output.
+func RunCommandLines(ctx context.Context, command string, args []string, env, secretEnv map[string]string, data TemplateData, onLine func(line string) bool) (string, int, error) {
+	cmd, err := BuildCommand(ctx, command, args, env, secretEnv, data)
 	if err != nil {
 		return "", -1, err
 	}
 
-	var output bytes.Buffer
-	cmd.Stdout = &output
-	cmd.Stderr = &output
+	output := &lineWriter{onLine: onLine}
+	cmd.Stdout = output
+	cmd.Stderr = output
 	err = cmd.Run()
+	output.flush()
 	exitCode := -1
 	if cmd.ProcessState != nil {
 		exitCode = cmd.ProcessState.ExitCode()
 	}
-	return output.String(), exitCode, err
+	return output.out.String(), exitCode, err
+}
+
+// RunCommandSplit executes a command like RunCommand but returns stdout and stderr separately.
+func RunCommandSplit(ctx context.Context, command string, args []string, env, secretEnv map[string]string, data TemplateData) (string, string, int, error) {
+	cmd, err := BuildCommand(ctx, command, args, env, secretEnv, data)
+	if err != nil {
+		return "", "", -1, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err = cmd.Run()
+	exitCode := -1
+	if cmd.ProcessState != nil {
+		exitCode = cmd.ProcessState.ExitCode()
+	}
+	return stdout.String(), stderr.String(), exitCode, err
+}
+
+// lineWriter collects command output, filtering complete lines through onLine.
+type lineWriter struct {
+	onLine  func(line string) bool
+	out     bytes.Buffer
+	partial bytes.Buffer
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	if w.onLine == nil {
+		return w.out.Write(p)
+	}
+	w.partial.Write(p)
+	for {
+		line, err := w.partial.ReadBytes('\n')
+		if err != nil {
+			// Keep the incomplete tail for the next write.
+			rest := append([]byte(nil), line...)
+			w.partial.Reset()
+			w.partial.Write(rest)
+			return len(p), nil
+		}
+		if !w.onLine(strings.TrimRight(string(line), "\r\n")) {
+			w.out.Write(line)
+		}
+	}
+}
+
+func (w *lineWriter) flush() {
+	if w.onLine == nil || w.partial.Len() == 0 {
+		return
+	}
+	line := w.partial.String()
+	w.partial.Reset()
+	if !w.onLine(line) {
+		w.out.WriteString(line)
+	}
 }
@@ -0,0 +1,2 @@
+// Package grants stores reusable approval grants for repeated identical tool calls.
+package grants
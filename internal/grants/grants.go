@@ -0,0 +1,93 @@
+package grants
+
+import (
+	"sync"
+	"time"
+)
+
+// Grant records an approval that later identical calls may reuse.
+type Grant struct {
+	// Approver names the approvers that approved the original call.
+	Approver string
+	// CorrelationID identifies the original call.
+	CorrelationID string
+	// ExpiresAt is when the grant stops being valid.
+	ExpiresAt time.Time
+	// Remaining is the number of uses left (unlimited when negative).
+	Remaining int
+	// Patch is the argument patch the approvers applied to the original call.
+	Patch map[string]any
+}
+
+// Store keeps grants in memory by key.
+type Store struct {
+	mu     sync.Mutex
+	grants map[string]*Grant
+	now    func() time.Time
+}
+
+// NewStore creates an empty store.
+func NewStore() *Store {
+	return &Store{grants: make(map[string]*Grant), now: time.Now}
+}
+
+// Put stores a grant for key valid for ttl and maxUses uses (unlimited when not positive);
+// patch is reapplied to the calls that use the grant.
+func (s *Store) Put(key, approverName, correlationID string, patch map[string]any, ttl time.Duration, maxUses int) Grant {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	for k, grant := range s.grants {
+		if !now.Before(grant.ExpiresAt) {
+			delete(s.grants, k)
+		}
+	}
+	remaining := maxUses
+	if remaining <= 0 {
+		remaining = -1
+	}
+	grant := &Grant{Approver: approverName, CorrelationID: correlationID, ExpiresAt: now.Add(ttl), Remaining: remaining, Patch: patch}
+	s.grants[key] = grant
+	return *grant
+}
+
+// Get returns the valid grant for key without consuming a use.
+func (s *Store) Get(key string) (Grant, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	grant, ok := s.valid(key)
+	if !ok {
+		return Grant{}, false
+	}
+	return *grant, true
+}
+
+// Use consumes one use of the grant for key; it reports false when there is no valid grant.
+func (s *Store) Use(key string) (Grant, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	grant, ok := s.valid(key)
+	if !ok {
+		return Grant{}, false
+	}
+	if grant.Remaining > 0 {
+		grant.Remaining--
+		if grant.Remaining == 0 {
+			delete(s.grants, key)
+		}
+	}
+	return *grant, true
+}
+
+// valid returns the grant for key, dropping it when expired. The caller holds s.mu.
+func (s *Store) valid(key string) (*Grant, bool) {
+	grant, ok := s.grants[key]
+	if !ok {
+		return nil, false
+	}
+	if !s.now().Before(grant.ExpiresAt) {
+		delete(s.grants, key)
+		return nil, false
+	}
+	return grant, true
+}
@@ -0,0 +1,2 @@
+// Package grpcclient manages shared gRPC client connections.
+package grpcclient
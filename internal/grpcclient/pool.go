@@ -0,0 +1,106 @@
+package grpcclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/tlsutil"
+)
+
+// Config describes a gRPC client connection.
+type Config struct {
+	// Address is the gRPC target (for example, "dns:///approver:9090").
+	Address string
+	// Plaintext disables TLS.
+	Plaintext bool
+	// TLS configures transport security when Plaintext is false.
+	TLS tlsutil.Config
+}
+
+// Pool shares client connections between approvers and executors.
+type Pool struct {
+	mu    sync.Mutex
+	conns map[Config]*grpc.ClientConn
+}
+
+// NewPool creates an empty connection pool.
+func NewPool() *Pool {
+	return &Pool{conns: make(map[Config]*grpc.ClientConn)}
+}
+
+// Dial returns a shared connection for cfg, creating it on first use.
+// Connections are established lazily by gRPC on the first RPC.
+func (p *Pool) Dial(cfg Config) (*grpc.ClientConn, error) {
+	cfg.Address = strings.TrimSpace(cfg.Address)
+	if cfg.Address == "" {
+		return nil, errors.New("grpc address is empty")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if conn, ok := p.conns[cfg]; ok {
+		return conn, nil
+	}
+
+	creds := insecure.NewCredentials()
+	if !cfg.Plaintext {
+		tlsCfg, err := cfg.TLS.Build()
+		if err != nil {
+			return nil, fmt.Errorf("grpc tls: %w", err)
+		}
+		creds = credentials.NewTLS(tlsCfg)
+	}
+	conn, err := grpc.NewClient(cfg.Address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("grpc client %s: %w", cfg.Address, err)
+	}
+	p.conns[cfg] = conn
+	return conn, nil
+}
+
+// Close closes all pooled connections.
+func (p *Pool) Close(context.Context) error {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	conns := p.conns
+	p.conns = make(map[Config]*grpc.ClientConn)
+	p.mu.Unlock()
+
+	var errs []error
+	for _, conn := range conns {
+		if err := conn.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WithHeaders attaches static headers as outgoing gRPC metadata.
+func WithHeaders(ctx context.Context, headers map[string]string) context.Context {
+	if len(headers) == 0 {
+		return ctx
+	}
+	pairs := make([]string, 0, len(headers)*2)
+	for key, value := range headers {
+		pairs = append(pairs, strings.ToLower(key), value)
+	}
+	return metadata.AppendToOutgoingContext(ctx, pairs...)
+}
+
+// ToStruct converts a JSON-like map into a protobuf Struct (nil for empty maps).
+func ToStruct(values map[string]any) (*structpb.Struct, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return structpb.NewStruct(values)
+}
@@ -1,12 +1,17 @@
 package health
 
 import (
+	"encoding/json"
 	"net/http"
+	"sync"
 	"sync/atomic"
 )
 
 type Handler struct {
 	ready atomic.Bool
+
+	mu      sync.RWMutex
+	details map[string]func() any
 }
 
 // New returns a health handler instance.
@@ -24,6 +29,20 @@ func (h *Handler) SetNotReady() {
 	h.ready.Store(false)
 }
 
+// AddDetail registers a named value reported in the readiness response body.
+// With details registered, /readyz responds with JSON instead of plain text.
+func (h *Handler) AddDetail(name string, fn func() any) {
+	if fn == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.details == nil {
+		h.details = map[string]func() any{}
+	}
+	h.details[name] = fn
+}
+
 // Healthz handles liveness probes.
 func (h *Handler) Healthz(w http.ResponseWriter, _ *http.Request) {
 	w.WriteHeader(http.StatusOK)
@@ -32,11 +51,26 @@ func (h *Handler) Healthz(w http.ResponseWriter, _ *http.Request) {
 
 // Readyz handles readiness probes.
 func (h *Handler) Readyz(w http.ResponseWriter, _ *http.Request) {
-	if h.ready.Load() {
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("ready"))
+	ready := h.ready.Load()
+	code, status := http.StatusOK, "ready"
+	if !ready {
+		code, status = http.StatusServiceUnavailable, "not ready"
+	}
+
+	h.mu.RLock()
+	body := make(map[string]any, len(h.details)+1)
+	for name, fn := range h.details {
+		body[name] = fn()
+	}
+	h.mu.RUnlock()
+	if len(body) == 0 {
+		w.WriteHeader(code)
+		_, _ = w.Write([]byte(status))
 		return
 	}
-	w.WriteHeader(http.StatusServiceUnavailable)
-	_, _ = w.Write([]byte("not ready"))
+
+	body["status"] = status
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
 }
@@ -0,0 +1,3 @@
+// Package httpclient builds outbound HTTP transports with authentication, signing, TLS and proxy settings
+// and posts cancellation and result notices to HTTP approvers and executors.
+package httpclient
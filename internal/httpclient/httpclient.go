@@ -0,0 +1,144 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/tlsutil"
+)
+
+// Default signature headers.
+const (
+	DefaultSignatureHeader = "X-YAML-MCP-Signature"
+	DefaultTimestampHeader = "X-YAML-MCP-Timestamp"
+)
+
+// Config describes an outbound client profile.
+type Config struct {
+	// BearerTokenFile is read into an Authorization header (re-read when the file changes).
+	BearerTokenFile string
+	// HMACKeyFile holds the signing key (re-read when the file changes).
+	HMACKeyFile string
+	// HMACKey returns the signing key when HMACKeyFile is empty; signing is disabled when both are unset.
+	HMACKey func(ctx context.Context) (string, error)
+	// SignatureHeader carries "sha256=<hex>" (DefaultSignatureHeader when empty).
+	SignatureHeader string
+	// TimestampHeader carries the signing unix timestamp (DefaultTimestampHeader when empty).
+	TimestampHeader string
+	// TLS configures client certificates and CA bundles (system defaults when nil).
+	TLS *tlsutil.Config
+	// Proxy is the proxy URL (environment proxy settings when empty).
+	Proxy string
+}
+
+// NewTransport returns a RoundTripper applying the profile to every request.
+func NewTransport(cfg Config) (http.RoundTripper, error) {
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.TLS != nil {
+		tlsCfg, err := cfg.TLS.Build()
+		if err != nil {
+			return nil, err
+		}
+		base.TLSClientConfig = tlsCfg
+	}
+	if proxy := strings.TrimSpace(cfg.Proxy); proxy != "" {
+		proxyURL, err := url.Parse(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("proxy is invalid: %w", err)
+		}
+		base.Proxy = http.ProxyURL(proxyURL)
+	}
+	out := &transport{base: base, hmacKey: cfg.HMACKey}
+	if path := strings.TrimSpace(cfg.BearerTokenFile); path != "" {
+		out.token = &fileValue{path: path}
+	}
+	if path := strings.TrimSpace(cfg.HMACKeyFile); path != "" {
+		key := &fileValue{path: path}
+		out.hmacKey = func(context.Context) (string, error) { return key.get() }
+	}
+	if out.hmacKey != nil {
+		out.signatureHeader = fallback(cfg.SignatureHeader, DefaultSignatureHeader)
+		out.timestampHeader = fallback(cfg.TimestampHeader, DefaultTimestampHeader)
+	}
+	return out, nil
+}
+
+// Sign returns the "sha256=<hex>" signature of "<timestamp>.<body>".
+func Sign(key, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type transport struct {
+	base            http.RoundTripper
+	token           *fileValue
+	hmacKey         func(ctx context.Context) (string, error)
+	signatureHeader string
+	timestampHeader string
+}
+
+// RoundTrip sets authentication headers and forwards the request.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.token == nil && t.hmacKey == nil {
+		return t.base.RoundTrip(req)
+	}
+	out := req.Clone(req.Context())
+	if t.token != nil {
+		token, err := t.token.get()
+		if err != nil {
+			return nil, err
+		}
+		out.Header.Set("Authorization", "Bearer "+token)
+	}
+	if t.hmacKey != nil {
+		key, err := t.hmacKey(req.Context())
+		if err != nil {
+			return nil, fmt.Errorf("hmac key: %w", err)
+		}
+		body, err := readBody(req)
+		if err != nil {
+			return nil, err
+		}
+		out.Body = io.NopCloser(bytes.NewReader(body))
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		out.Header.Set(t.timestampHeader, timestamp)
+		out.Header.Set(t.signatureHeader, Sign(key, timestamp, body))
+	}
+	return t.base.RoundTrip(out)
+}
+
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody == nil {
+		return nil, errors.New("request body cannot be signed")
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return io.ReadAll(body)
+}
+
+func fallback(value, def string) string {
+	if strings.TrimSpace(value) == "" {
+		return def
+	}
+	return strings.TrimSpace(value)
+}
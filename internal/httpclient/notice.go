@@ -0,0 +1,64 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/protocol"
+)
+
+// NoticeTimeout bounds a single notice request.
+const NoticeTimeout = 10 * time.Second
+
+// CancelReason maps a finished context to a protocol cancellation reason.
+func CancelReason(ctx context.Context) string {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return protocol.CancelReasonTimeout
+	}
+	return protocol.CancelReasonCancelled
+}
+
+// Cancel posts a CancelRequest to url. It detaches from ctx, which is already done when a call is cancelled.
+func Cancel(ctx context.Context, transport http.RoundTripper, url, correlationID, tool string, headers ...map[string]string) error {
+	return PostJSON(ctx, transport, url, protocol.CancelRequest{
+		CorrelationID: correlationID,
+		Tool:          tool,
+		Reason:        CancelReason(ctx),
+	}, headers...)
+}
+
+// PostJSON posts payload to url, ignoring cancellation of ctx and bounding the request by NoticeTimeout.
+func PostJSON(ctx context.Context, transport http.RoundTripper, url string, payload any, headers ...map[string]string) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode notice: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), NoticeTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build notice request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for _, set := range headers {
+		for key, value := range set {
+			request.Header.Set(key, value)
+		}
+	}
+	resp, err := (&http.Client{Transport: transport}).Do(request)
+	if err != nil {
+		return fmt.Errorf("notice request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notice status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,2 @@
+// Package operations tracks deferred tool calls running in the background.
+package operations
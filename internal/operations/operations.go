@@ -0,0 +1,100 @@
+package operations
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/protocol"
+)
+
+// DefaultRetention is how long finished operations stay queryable.
+const DefaultRetention = 24 * time.Hour
+
+var (
+	// ErrRunning is returned when the operation is still running.
+	ErrRunning = errors.New("operation is already running")
+	// ErrOtherSession is returned when the correlation ID belongs to an operation of another session.
+	ErrOtherSession = errors.New("correlation id is used by another session")
+)
+
+// Operation is a deferred tool call.
+type Operation struct {
+	// Tool is the tool name.
+	Tool string
+	// SessionID is the MCP session that started the operation (empty for stdio).
+	SessionID string
+	// CorrelationID identifies the operation.
+	CorrelationID string
+	// Done reports whether Response is final.
+	Done bool
+	// Response is the final tool response (set when Done).
+	Response protocol.ToolResponse
+	// StartedAt is when the operation started.
+	StartedAt time.Time
+	// FinishedAt is when the operation finished.
+	FinishedAt time.Time
+}
+
+// Store keeps deferred operations in memory.
+type Store struct {
+	retention time.Duration
+
+	mu  sync.Mutex
+	ops map[string]*Operation
+}
+
+// NewStore creates a store keeping finished operations for retention (DefaultRetention when not positive).
+func NewStore(retention time.Duration) *Store {
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+	return &Store{retention: retention, ops: make(map[string]*Operation)}
+}
+
+// Start registers a running operation for sessionID. It fails with ErrRunning while one with the
+// same correlation ID is still running and with ErrOtherSession while another session's is kept.
+func (s *Store) Start(tool, correlationID, sessionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	for id, op := range s.ops {
+		if op.Done && now.Sub(op.FinishedAt) > s.retention {
+			delete(s.ops, id)
+		}
+	}
+	if op, ok := s.ops[correlationID]; ok {
+		if op.SessionID != sessionID {
+			return ErrOtherSession
+		}
+		if !op.Done {
+			return ErrRunning
+		}
+	}
+	s.ops[correlationID] = &Operation{Tool: tool, SessionID: sessionID, CorrelationID: correlationID, StartedAt: now}
+	return nil
+}
+
+// Finish stores the final response of an operation.
+func (s *Store) Finish(correlationID string, resp protocol.ToolResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	op, ok := s.ops[correlationID]
+	if !ok {
+		return
+	}
+	op.Done = true
+	op.Response = resp
+	op.FinishedAt = time.Now()
+}
+
+// Get returns a copy of the operation started by sessionID; operations of other sessions are not found.
+func (s *Store) Get(correlationID, sessionID string) (Operation, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	op, ok := s.ops[correlationID]
+	if !ok || op.SessionID != sessionID {
+		return Operation{}, false
+	}
+	return *op, true
+}
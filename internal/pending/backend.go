@@ -0,0 +1,121 @@
+package pending
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// DefaultTTL is how long a sent request is kept for its callback when the store TTL is not set.
+const DefaultTTL = 24 * time.Hour
+
+// Deadline returns when the caller stops waiting: the context deadline, or now+timeout
+// (zero when neither is set). Register keeps the record at least until then.
+func Deadline(ctx context.Context, timeout time.Duration) time.Time {
+	if deadline, ok := ctx.Deadline(); ok {
+		return deadline
+	}
+	if timeout <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(timeout)
+}
+
+// Record is a pending async request as stored by a Backend.
+type Record struct {
+	// CorrelationID identifies the request.
+	CorrelationID string `json:"correlation_id"`
+	// Source identifies the approver or executor that issued the request.
+	Source string `json:"source,omitempty"`
+	// Tool is the tool whose call issued the request.
+	Tool string `json:"tool,omitempty"`
+	// ArgsHash is the ArgumentsHash of the call arguments.
+	ArgsHash string `json:"args_hash,omitempty"`
+	// Token is the single-use callback token.
+	Token string `json:"token,omitempty"`
+	// Auth names the webhook verifier required for callbacks (optional).
+	Auth string `json:"auth,omitempty"`
+	// Deadline is when the request expires.
+	Deadline time.Time `json:"deadline"`
+	// Resolved reports whether a callback delivered Result.
+	Resolved bool `json:"resolved,omitempty"`
+	// Result is the delivered decision or execution result.
+	Result json.RawMessage `json:"result,omitempty"`
+}
+
+// ArgumentsHash returns the hash that binds a record to the arguments of its call.
+func ArgumentsHash(args map[string]any) (string, error) {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Expired reports whether the record is past its deadline.
+func (r Record) Expired(now time.Time) bool {
+	return !r.Deadline.IsZero() && now.After(r.Deadline)
+}
+
+// Backend stores pending records.
+type Backend interface {
+	// Get returns the record for correlationID.
+	Get(correlationID string) (Record, bool, error)
+	// Put creates or replaces a record.
+	Put(record Record) error
+	// Delete removes a record.
+	Delete(correlationID string) error
+	// Prune removes records expired at now.
+	Prune(now time.Time) error
+}
+
+// Memory is an in-process Backend; records are lost on restart.
+type Memory struct {
+	mu      sync.Mutex
+	records map[string]Record
+}
+
+// NewMemory creates an empty in-memory backend.
+func NewMemory() *Memory {
+	return &Memory{records: make(map[string]Record)}
+}
+
+// Get returns the record for correlationID.
+func (m *Memory) Get(correlationID string) (Record, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	record, ok := m.records[correlationID]
+	return record, ok, nil
+}
+
+// Put creates or replaces a record.
+func (m *Memory) Put(record Record) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.records[record.CorrelationID] = record
+	return nil
+}
+
+// Delete removes a record.
+func (m *Memory) Delete(correlationID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.records, correlationID)
+	return nil
+}
+
+// Prune removes records expired at now.
+func (m *Memory) Prune(now time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, record := range m.records {
+		if record.Expired(now) {
+			delete(m.records, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package pending
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// DB is a bbolt file holding one bucket per pending store.
+type DB struct {
+	db *bolt.DB
+}
+
+// OpenDB opens (or creates) a bbolt file at path.
+func OpenDB(path string) (*DB, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("create pending store dir: %w", err)
+	}
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("open pending store: %w", err)
+	}
+	return &DB{db: db}, nil
+}
+
+// Bucket returns a Backend stored in the named bucket.
+func (d *DB) Bucket(name string) (Backend, error) {
+	err := d.db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists([]byte(name))
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create pending bucket %s: %w", name, err)
+	}
+	return boltBucket{db: d.db, name: []byte(name)}, nil
+}
+
+// Close closes the underlying file.
+func (d *DB) Close() error {
+	return d.db.Close()
+}
+
+type boltBucket struct {
+	db   *bolt.DB
+	name []byte
+}
+
+func (b boltBucket) Get(correlationID string) (Record, bool, error) {
+	var (
+		record Record
+		found  bool
+	)
+	err := b.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(b.name).Get([]byte(correlationID))
+		if data == nil {
+			return nil
+		}
+		found = true
+		return json.Unmarshal(data, &record)
+	})
+	return record, found, err
+}
+
+func (b boltBucket) Put(record Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(b.name).Put([]byte(record.CorrelationID), data)
+	})
+}
+
+func (b boltBucket) Delete(correlationID string) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(b.name).Delete([]byte(correlationID))
+	})
+}
+
+func (b boltBucket) Prune(now time.Time) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(b.name)
+		var expired [][]byte
+		err := bucket.ForEach(func(key, value []byte) error {
+			var record Record
+			if err := json.Unmarshal(value, &record); err != nil || record.Expired(now) {
+				expired = append(expired, append([]byte(nil), key...))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, key := range expired {
+			if err := bucket.Delete(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,3 @@
+// Package pending keeps async approval and execution requests until a webhook resolves them,
+// optionally persisting them across restarts.
+package pending
@@ -0,0 +1,223 @@
+package pending
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/webhookauth"
+)
+
+// State reports what Register found for a correlation ID.
+type State int
+
+const (
+	// StateNew means the request must be sent.
+	StateNew State = iota
+	// StatePending means the request was already sent and still awaits a callback.
+	StatePending
+	// StateResolved means a callback already delivered the result.
+	StateResolved
+)
+
+var (
+	// ErrAlreadyPending is returned when another caller already waits for the correlation ID.
+	ErrAlreadyPending = errors.New("request already pending")
+	// ErrNotPending is returned for callbacks without a matching pending request.
+	ErrNotPending = errors.New("request not pending")
+	// ErrMismatch is returned when the correlation ID is pending for another tool or other arguments.
+	ErrMismatch = errors.New("correlation id is pending for a different call")
+)
+
+// Store tracks pending requests in a Backend and delivers results of type T to waiting callers.
+type Store[T any] struct {
+	backend Backend
+	ttl     time.Duration
+
+	mu        sync.Mutex
+	waiters   map[string]chan T
+	progress  map[string]func(message string, percent *float64)
+	verifiers map[string]*webhookauth.Verifier
+}
+
+// NewStore creates a store on top of backend (in-memory when nil). Records are kept for ttl
+// (DefaultTTL when not positive) after the request is sent, so a callback that arrives after
+// the caller timed out is still accepted and a retry can pick it up.
+func NewStore[T any](backend Backend, ttl time.Duration) *Store[T] {
+	if backend == nil {
+		backend = NewMemory()
+	}
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Store[T]{
+		backend:   backend,
+		ttl:       ttl,
+		waiters:   make(map[string]chan T),
+		progress:  make(map[string]func(message string, percent *float64)),
+		verifiers: make(map[string]*webhookauth.Verifier),
+	}
+}
+
+// AddVerifier makes a webhook verifier known before any caller registers,
+// so callbacks for requests persisted before a restart can be verified.
+func (s *Store[T]) AddVerifier(verifier *webhookauth.Verifier) {
+	if verifier == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.verifiers[verifier.Name()] = verifier
+}
+
+// Register records a pending request, or resumes an unexpired one from the same source.
+// A request pending for another tool or other arguments is never resumed (ErrMismatch).
+// The returned channel receives the result; for StateResolved it is already filled.
+func (s *Store[T]) Register(record Record, auth *webhookauth.Verifier) (<-chan T, State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := record.CorrelationID
+	if _, waiting := s.waiters[id]; waiting {
+		return nil, StateNew, ErrAlreadyPending
+	}
+	now := time.Now()
+	if err := s.backend.Prune(now); err != nil {
+		return nil, StateNew, fmt.Errorf("prune pending store: %w", err)
+	}
+	existing, ok, err := s.backend.Get(id)
+	if err != nil {
+		return nil, StateNew, fmt.Errorf("read pending store: %w", err)
+	}
+	if ok && !existing.Expired(now) && (existing.Tool != record.Tool || existing.ArgsHash != record.ArgsHash) {
+		return nil, StateNew, ErrMismatch
+	}
+	ch := make(chan T, 1)
+	if ok && existing.Source == record.Source && !existing.Expired(now) {
+		if existing.Resolved {
+			var value T
+			if err := json.Unmarshal(existing.Result, &value); err != nil {
+				return nil, StateNew, fmt.Errorf("decode pending result: %w", err)
+			}
+			ch <- value
+			close(ch)
+			return ch, StateResolved, nil
+		}
+		s.waiters[id] = ch
+		return ch, StatePending, nil
+	}
+	if expires := now.Add(s.ttl); record.Deadline.Before(expires) {
+		record.Deadline = expires
+	}
+	if auth != nil {
+		record.Auth = auth.Name()
+		s.verifiers[record.Auth] = auth
+	}
+	if err := s.backend.Put(record); err != nil {
+		return nil, StateNew, fmt.Errorf("write pending store: %w", err)
+	}
+	s.waiters[id] = ch
+	return ch, StateNew, nil
+}
+
+// Authorize checks a callback against the pending request it targets.
+func (s *Store[T]) Authorize(r *http.Request, body []byte, correlationID, token string) error {
+	record, ok, err := s.backend.Get(correlationID)
+	if err != nil {
+		return fmt.Errorf("read pending store: %w", err)
+	}
+	if !ok || record.Resolved || record.Expired(time.Now()) {
+		return ErrNotPending
+	}
+	if record.Auth != "" {
+		s.mu.Lock()
+		verifier := s.verifiers[record.Auth]
+		s.mu.Unlock()
+		if verifier == nil {
+			return fmt.Errorf("%w: verifier %s is not configured", webhookauth.ErrUnauthorized, record.Auth)
+		}
+		if err := verifier.Verify(r, body); err != nil {
+			return err
+		}
+	}
+	return webhookauth.CheckToken(record.Token, token)
+}
+
+// Resolve stores the result and delivers it to the waiting caller, if any.
+// It returns false when no unresolved request exists for correlationID.
+func (s *Store[T]) Resolve(correlationID string, value T) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record, ok, err := s.backend.Get(correlationID)
+	if err != nil {
+		return false, fmt.Errorf("read pending store: %w", err)
+	}
+	if !ok || record.Resolved || record.Expired(time.Now()) {
+		return false, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("encode pending result: %w", err)
+	}
+	record.Resolved = true
+	record.Result = data
+	if err := s.backend.Put(record); err != nil {
+		return false, fmt.Errorf("write pending store: %w", err)
+	}
+	delete(s.progress, correlationID)
+	if ch, waiting := s.waiters[correlationID]; waiting {
+		delete(s.waiters, correlationID)
+		ch <- value
+		close(ch)
+	}
+	return true, nil
+}
+
+// OnProgress forwards progress callbacks for a registered request to fn until it is cancelled or completed.
+func (s *Store[T]) OnProgress(correlationID string, fn func(message string, percent *float64)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, waiting := s.waiters[correlationID]; waiting {
+		s.progress[correlationID] = fn
+	}
+}
+
+// Progress delivers an intermediate update to the waiting caller, if any.
+// It returns false when no unresolved request exists for correlationID.
+func (s *Store[T]) Progress(correlationID, message string, percent *float64) (bool, error) {
+	record, ok, err := s.backend.Get(correlationID)
+	if err != nil {
+		return false, fmt.Errorf("read pending store: %w", err)
+	}
+	if !ok || record.Resolved || record.Expired(time.Now()) {
+		return false, nil
+	}
+	s.mu.Lock()
+	fn := s.progress[correlationID]
+	s.mu.Unlock()
+	if fn != nil {
+		fn(message, percent)
+	}
+	return true, nil
+}
+
+// Cancel stops waiting but keeps the record, so a later call can pick up the result.
+func (s *Store[T]) Cancel(correlationID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.progress, correlationID)
+	if ch, waiting := s.waiters[correlationID]; waiting {
+		delete(s.waiters, correlationID)
+		close(ch)
+	}
+}
+
+// Complete stops waiting and removes the record.
+func (s *Store[T]) Complete(correlationID string) error {
+	s.Cancel(correlationID)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.backend.Delete(correlationID)
+}
@@ -0,0 +1,137 @@
+package pending
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/webhookauth"
+)
+
+func TestStoreAuthorize(t *testing.T) {
+	body := []byte(`{"correlation_id":"corr-1"}`)
+	verifier := webhookauth.NewVerifier(webhookauth.Config{
+		Name: "tool/approvers[0]",
+		Key:  func(context.Context) (string, error) { return "key", nil },
+	})
+	tests := []struct {
+		name     string
+		auth     *webhookauth.Verifier
+		id       string
+		token    string
+		signed   bool
+		resolved bool
+		wantErr  error
+	}{
+		{name: "token without webhook auth", id: "corr-1", token: "tok"},
+		{name: "missing token without webhook auth", id: "corr-1", wantErr: webhookauth.ErrUnauthorized},
+		{name: "wrong token without webhook auth", id: "corr-1", token: "other", wantErr: webhookauth.ErrUnauthorized},
+		{name: "signed with token", auth: verifier, id: "corr-1", token: "tok", signed: true},
+		{name: "unsigned with webhook auth", auth: verifier, id: "corr-1", token: "tok", wantErr: webhookauth.ErrUnauthorized},
+		{name: "signed without token", auth: verifier, id: "corr-1", signed: true, wantErr: webhookauth.ErrUnauthorized},
+		{name: "unknown correlation id", id: "corr-2", token: "tok", wantErr: ErrNotPending},
+		{name: "already resolved", id: "corr-1", token: "tok", resolved: true, wantErr: ErrNotPending},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewStore[string](nil, time.Hour)
+			if _, _, err := store.Register(Record{CorrelationID: "corr-1", Source: "http", Token: "tok"}, tt.auth); err != nil {
+				t.Fatal(err)
+			}
+			if tt.resolved {
+				if _, err := store.Resolve("corr-1", "done"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			r := httptest.NewRequest(http.MethodPost, "/webhook", nil)
+			if tt.signed {
+				timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+				r.Header.Set(webhookauth.DefaultTimestampHeader, timestamp)
+				r.Header.Set(webhookauth.DefaultNonceHeader, "nonce")
+				r.Header.Set(webhookauth.DefaultSignatureHeader, webhookauth.Sign("key", timestamp, "nonce", body))
+			}
+			err := store.Authorize(r, body, tt.id, tt.token)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("Authorize() error = %v", err)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Authorize() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStoreRegisterResume(t *testing.T) {
+	first := Record{CorrelationID: "corr-1", Source: "http", Tool: "deploy", ArgsHash: "a1", Token: "tok"}
+	tests := []struct {
+		name      string
+		resolved  bool
+		expired   bool
+		next      Record
+		wantState State
+		wantErr   error
+	}{
+		{name: "same call resumes pending", next: first, wantState: StatePending},
+		{name: "same call picks up result", resolved: true, next: first, wantState: StateResolved},
+		{name: "other tool is rejected", resolved: true, next: Record{CorrelationID: "corr-1", Source: "http", Tool: "delete", ArgsHash: "a1"}, wantErr: ErrMismatch},
+		{name: "other arguments are rejected", resolved: true, next: Record{CorrelationID: "corr-1", Source: "http", Tool: "deploy", ArgsHash: "a2"}, wantErr: ErrMismatch},
+		{name: "other source starts over", next: Record{CorrelationID: "corr-1", Source: "lead", Tool: "deploy", ArgsHash: "a1"}, wantState: StateNew},
+		{name: "expired record starts over", expired: true, next: Record{CorrelationID: "corr-1", Source: "http", Tool: "delete", ArgsHash: "a2"}, wantState: StateNew},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := NewMemory()
+			store := NewStore[string](backend, time.Hour)
+			if _, _, err := store.Register(first, nil); err != nil {
+				t.Fatal(err)
+			}
+			if tt.resolved {
+				if _, err := store.Resolve("corr-1", "done"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			store.Cancel("corr-1")
+			if tt.expired {
+				record, _, _ := backend.Get("corr-1")
+				record.Deadline = time.Now().Add(-time.Minute)
+				if err := backend.Put(record); err != nil {
+					t.Fatal(err)
+				}
+			}
+			ch, state, err := store.Register(tt.next, nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Register() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if state != tt.wantState {
+				t.Fatalf("Register() state = %v, want %v", state, tt.wantState)
+			}
+			if state == StateResolved {
+				if got := <-ch; got != "done" {
+					t.Fatalf("result = %q, want done", got)
+				}
+			}
+		})
+	}
+}
+
+func TestArgumentsHash(t *testing.T) {
+	a, err := ArgumentsHash(map[string]any{"env": "prod", "replicas": 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := ArgumentsHash(map[string]any{"replicas": 3, "env": "prod"})
+	c, _ := ArgumentsHash(map[string]any{"env": "staging", "replicas": 3})
+	if a != b {
+		t.Fatal("hash depends on key order")
+	}
+	if a == c {
+		t.Fatal("hash ignores argument values")
+	}
+}
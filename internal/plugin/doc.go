@@ -0,0 +1,2 @@
+// Package plugin runs long-lived subprocess plugins speaking JSON-RPC over stdio.
+package plugin
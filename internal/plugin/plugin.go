@@ -0,0 +1,414 @@
+package plugin
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os/exec"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/executil"
+)
+
+// JSON-RPC methods understood by plugins.
+const (
+	MethodApprove  = "approve"
+	MethodExecute  = "execute"
+	MethodPing     = "ping"
+	MethodCancel   = "cancel"
+	MethodShutdown = "shutdown"
+)
+
+const (
+	defaultPingInterval = 30 * time.Second
+	pingTimeout         = 5 * time.Second
+	minBackoff          = 500 * time.Millisecond
+	maxBackoff          = 30 * time.Second
+	// stableRun resets the restart backoff when a process survived this long.
+	stableRun = time.Minute
+)
+
+// ErrNotRunning is returned when the plugin process is not available.
+var ErrNotRunning = errors.New("plugin is not running")
+
+// Config describes a plugin process.
+type Config struct {
+	// Name is a human-friendly plugin name used in logs.
+	Name string
+	// Command is the plugin executable or shell command.
+	Command string
+	// Args are plugin arguments.
+	Args []string
+	// Env adds environment variables for the plugin.
+	Env map[string]string
+	// PingInterval controls health ping frequency (default 30s).
+	PingInterval time.Duration
+}
+
+type rpcMessage struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      *int64          `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  any             `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResult struct {
+	result json.RawMessage
+	err    error
+}
+
+// instance is a single running plugin process.
+type instance struct {
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	writeMu sync.Mutex
+	mu      sync.Mutex
+	pending map[int64]chan rpcResult
+	exited  chan struct{}
+}
+
+// Process supervises a plugin, restarting it with backoff when it exits.
+type Process struct {
+	cfg    Config
+	logger *slog.Logger
+
+	nextID  atomic.Int64
+	mu      sync.Mutex
+	current *instance
+	// ready is closed while current is running and replaced when it exits.
+	ready  chan struct{}
+	closed bool
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+// NewProcess creates a plugin supervisor. Call Start to launch the process.
+func NewProcess(cfg Config, logger *slog.Logger) *Process {
+	if cfg.PingInterval <= 0 {
+		cfg.PingInterval = defaultPingInterval
+	}
+	return &Process{
+		cfg:    cfg,
+		logger: logger,
+		ready:  make(chan struct{}),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+}
+
+// Name returns the plugin name.
+func (p *Process) Name() string {
+	if p.cfg.Name != "" {
+		return p.cfg.Name
+	}
+	return "plugin"
+}
+
+// Start launches the plugin and its supervisor loop.
+func (p *Process) Start() {
+	go p.supervise()
+}
+
+// Call sends a JSON-RPC request and decodes the result into out.
+// While the plugin is starting or restarting it waits for the process until ctx is done.
+func (p *Process) Call(ctx context.Context, method string, params, out any) error {
+	id := p.nextID.Add(1)
+	ch := make(chan rpcResult, 1)
+	var inst *instance
+	for inst == nil {
+		var err error
+		if inst, err = p.running(ctx); err != nil {
+			return err
+		}
+		inst.mu.Lock()
+		if inst.pending == nil {
+			// The process is exiting; wait for the supervisor to restart it.
+			inst.mu.Unlock()
+			select {
+			case <-inst.exited:
+			case <-ctx.Done():
+				return fmt.Errorf("%s: %w: %w", p.Name(), ErrNotRunning, ctx.Err())
+			}
+			inst = nil
+			continue
+		}
+		inst.pending[id] = ch
+		inst.mu.Unlock()
+	}
+
+	if err := inst.send(rpcMessage{JSONRPC: "2.0", ID: &id, Method: method, Params: params}); err != nil {
+		inst.forget(id)
+		return fmt.Errorf("%s: send %s: %w", p.Name(), method, err)
+	}
+
+	select {
+	case res := <-ch:
+		if res.err != nil {
+			return res.err
+		}
+		if out == nil || len(res.result) == 0 {
+			return nil
+		}
+		if err := json.Unmarshal(res.result, out); err != nil {
+			return fmt.Errorf("%s: decode %s result: %w", p.Name(), method, err)
+		}
+		return nil
+	case <-ctx.Done():
+		inst.forget(id)
+		_ = inst.send(rpcMessage{JSONRPC: "2.0", Method: MethodCancel, Params: map[string]any{"id": id}})
+		return ctx.Err()
+	}
+}
+
+// running returns the running instance, waiting for a start or restart until ctx is done.
+func (p *Process) running(ctx context.Context) (*instance, error) {
+	for {
+		p.mu.Lock()
+		inst, ready, closed := p.current, p.ready, p.closed
+		p.mu.Unlock()
+		if closed {
+			return nil, fmt.Errorf("%s: %w", p.Name(), ErrNotRunning)
+		}
+		if inst != nil {
+			return inst, nil
+		}
+		select {
+		case <-ready:
+		case <-p.stop:
+			return nil, fmt.Errorf("%s: %w", p.Name(), ErrNotRunning)
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%s: %w: %w", p.Name(), ErrNotRunning, ctx.Err())
+		}
+	}
+}
+
+// Close asks the plugin to shut down and waits for it to exit or ctx to expire.
+func (p *Process) Close(ctx context.Context) error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	inst := p.current
+	p.mu.Unlock()
+	close(p.stop)
+
+	if inst != nil {
+		_ = inst.send(rpcMessage{JSONRPC: "2.0", Method: MethodShutdown})
+		_ = inst.stdin.Close()
+		select {
+		case <-inst.exited:
+		case <-ctx.Done():
+			_ = inst.cmd.Process.Kill()
+		}
+	}
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%s: shutdown: %w", p.Name(), ctx.Err())
+	}
+}
+
+func (p *Process) supervise() {
+	defer close(p.done)
+	backoff := minBackoff
+	for {
+		started := time.Now()
+		inst, err := p.launch()
+		if err != nil {
+			p.log().Error("plugin start failed", "plugin", p.Name(), "error", err)
+		} else {
+			p.log().Info("plugin started", "plugin", p.Name(), "pid", inst.cmd.Process.Pid)
+			pingDone := make(chan struct{})
+			go p.pingLoop(inst, pingDone)
+			<-inst.exited
+			close(pingDone)
+			if time.Since(started) > stableRun {
+				backoff = minBackoff
+			}
+		}
+
+		select {
+		case <-p.stop:
+			return
+		default:
+		}
+		p.log().Warn("plugin exited, restarting", "plugin", p.Name(), "backoff", backoff.String())
+		select {
+		case <-p.stop:
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+func (p *Process) launch() (*instance, error) {
+	cmd, err := executil.BuildCommand(context.Background(), p.cfg.Command, p.cfg.Args, p.cfg.Env, nil, executil.TemplateData{})
+	if err != nil {
+		return nil, err
+	}
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return nil, errors.New("plugin is closed")
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	inst := &instance{
+		cmd:     cmd,
+		stdin:   stdin,
+		pending: make(map[int64]chan rpcResult),
+		exited:  make(chan struct{}),
+	}
+	p.current = inst
+	close(p.ready)
+
+	go p.logStderr(stderr)
+	go func() {
+		p.readLoop(inst, stdout)
+		err := cmd.Wait()
+		inst.failPending(fmt.Errorf("%s: %w: process exited: %v", p.Name(), ErrNotRunning, err))
+		// Clear current before exited is closed, so callers waiting on exited wait for the restart.
+		p.mu.Lock()
+		if p.current == inst {
+			p.current = nil
+			p.ready = make(chan struct{})
+		}
+		p.mu.Unlock()
+		close(inst.exited)
+	}()
+	return inst, nil
+}
+
+func (p *Process) readLoop(inst *instance, stdout io.Reader) {
+	reader := bufio.NewReader(stdout)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			p.dispatch(inst, line)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (p *Process) dispatch(inst *instance, line []byte) {
+	var msg rpcMessage
+	if err := json.Unmarshal(line, &msg); err != nil {
+		p.log().Warn("plugin sent invalid json", "plugin", p.Name(), "error", err)
+		return
+	}
+	if msg.ID == nil {
+		return
+	}
+	inst.mu.Lock()
+	ch, ok := inst.pending[*msg.ID]
+	delete(inst.pending, *msg.ID)
+	inst.mu.Unlock()
+	if !ok {
+		return
+	}
+	if msg.Error != nil {
+		ch <- rpcResult{err: fmt.Errorf("%s: rpc error %d: %s", p.Name(), msg.Error.Code, msg.Error.Message)}
+		return
+	}
+	ch <- rpcResult{result: msg.Result}
+}
+
+func (p *Process) pingLoop(inst *instance, done <-chan struct{}) {
+	ticker := time.NewTicker(p.cfg.PingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+			err := p.Call(ctx, MethodPing, nil, nil)
+			cancel()
+			if err != nil {
+				p.log().Warn("plugin ping failed, killing process", "plugin", p.Name(), "error", err)
+				_ = inst.cmd.Process.Kill()
+				return
+			}
+		}
+	}
+}
+
+func (p *Process) logStderr(stderr io.Reader) {
+	scanner := bufio.NewScanner(stderr)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" {
+			p.log().Info("plugin stderr", "plugin", p.Name(), "line", line)
+		}
+	}
+}
+
+func (p *Process) log() *slog.Logger {
+	if p.logger != nil {
+		return p.logger
+	}
+	return slog.New(slog.DiscardHandler)
+}
+
+func (i *instance) send(msg rpcMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	i.writeMu.Lock()
+	defer i.writeMu.Unlock()
+	_, err = i.stdin.Write(data)
+	return err
+}
+
+func (i *instance) forget(id int64) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.pending, id)
+}
+
+func (i *instance) failPending(err error) {
+	i.mu.Lock()
+	pending := i.pending
+	i.pending = nil
+	i.mu.Unlock()
+	for _, ch := range pending {
+		ch <- rpcResult{err: err}
+	}
+}
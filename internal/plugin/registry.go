@@ -0,0 +1,79 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Registry shares plugin processes between approvers and executors.
+type Registry struct {
+	mu     sync.Mutex
+	procs  map[string]*Process
+	logger *slog.Logger
+}
+
+// NewRegistry creates an empty plugin registry.
+func NewRegistry(logger *slog.Logger) *Registry {
+	return &Registry{procs: make(map[string]*Process), logger: logger}
+}
+
+// Get returns a running plugin for cfg, starting it on first use.
+// Configs with the same command, args and env share one process.
+func (r *Registry) Get(cfg Config) *Process {
+	key := registryKey(cfg)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if proc, ok := r.procs[key]; ok {
+		return proc
+	}
+	proc := NewProcess(cfg, r.logger)
+	proc.Start()
+	r.procs[key] = proc
+	return proc
+}
+
+// Close shuts down all plugin processes.
+func (r *Registry) Close(ctx context.Context) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	procs := make([]*Process, 0, len(r.procs))
+	for _, proc := range r.procs {
+		procs = append(procs, proc)
+	}
+	r.procs = make(map[string]*Process)
+	r.mu.Unlock()
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for _, proc := range procs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := proc.Close(ctx); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func registryKey(cfg Config) string {
+	env := make([]string, 0, len(cfg.Env))
+	for key, value := range cfg.Env {
+		env = append(env, key+"="+value)
+	}
+	sort.Strings(env)
+	return strings.Join([]string{cfg.Command, strings.Join(cfg.Args, "\x00"), strings.Join(env, "\x00")}, "\x01")
+}
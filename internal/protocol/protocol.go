@@ -2,18 +2,28 @@ package protocol
 
 // Tool execution statuses.
 const (
-	StatusSuccess = "success"
-	StatusDenied  = "denied"
-	StatusError   = "error"
-	StatusPending = "pending"
+	StatusSuccess   = "success"
+	StatusDenied    = "denied"
+	StatusError     = "error"
+	StatusPending   = "pending"
+	StatusProgress  = "progress"
+	StatusCancelled = "cancelled"
+	StatusNeedsInfo = "needs_info"
+)
+
+// Cancellation reasons.
+const (
+	CancelReasonCancelled = "cancelled"
+	CancelReasonTimeout   = "timeout"
 )
 
 // Approval decisions.
 const (
-	DecisionApprove = "approve"
-	DecisionDeny    = "deny"
-	DecisionError   = "error"
-	DecisionPending = "pending"
+	DecisionApprove   = "approve"
+	DecisionDeny      = "deny"
+	DecisionError     = "error"
+	DecisionPending   = "pending"
+	DecisionNeedsInfo = "needs_info"
 )
 
 // ToolResponse is the fixed JSON response returned to MCP clients.
@@ -26,6 +36,10 @@ type ToolResponse struct {
 	Reason string `json:"reason,omitempty"`
 	// CorrelationID links related requests.
 	CorrelationID string `json:"correlation_id"`
+	// Arguments are the effective arguments when approvers patched them.
+	Arguments map[string]any `json:"arguments,omitempty"`
+	// Questions are the approver's questions when the status is needs_info.
+	Questions []string `json:"questions,omitempty"`
 }
 
 // ApproverResponse is the fixed JSON response expected from HTTP approvers.
@@ -38,6 +52,10 @@ type ApproverResponse struct {
 	CorrelationID string `json:"correlation_id,omitempty"`
 	// RequestID is an optional external identifier.
 	RequestID string `json:"request_id,omitempty"`
+	// Patch optionally replaces top-level arguments on approve (null removes a field).
+	Patch map[string]any `json:"patch,omitempty"`
+	// Questions are asked back to the model with a needs_info decision.
+	Questions []string `json:"questions,omitempty"`
 }
 
 // ApproverLink defines a human-friendly link.
@@ -52,6 +70,8 @@ type ApproverLink struct {
 type ApproverCallback struct {
 	// URL is the webhook URL for decision callbacks.
 	URL string `json:"url"`
+	// Token is a single-use token the decision callback must echo back.
+	Token string `json:"token,omitempty"`
 }
 
 // ApproverRequest is the payload sent to HTTP approvers.
@@ -70,6 +90,8 @@ type ApproverRequest struct {
 	RiskAssessment string `json:"risk_assessment,omitempty"`
 	// LinksToCode are optional code references.
 	LinksToCode []ApproverLink `json:"links_to_code,omitempty"`
+	// Diff previews the changes the tool will make (unified diff).
+	Diff string `json:"diff,omitempty"`
 	// Lang selects message language (ru/en).
 	Lang string `json:"lang,omitempty"`
 	// Markup selects message formatting (markdown/html).
@@ -78,6 +100,22 @@ type ApproverRequest struct {
 	TimeoutSec int `json:"timeout_sec,omitempty"`
 	// Callback defines webhook settings for async approvers.
 	Callback *ApproverCallback `json:"callback,omitempty"`
+	// PatchableFields lists the arguments the approver may change via patch.
+	PatchableFields []string `json:"patchable_fields,omitempty"`
+	// FollowUp links a resubmission to the questions asked earlier for the same correlation_id.
+	FollowUp *ApproverFollowUp `json:"follow_up,omitempty"`
+	// Metadata is the combined metadata returned by earlier approvers in the chain.
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// ApproverFollowUp describes the needs_info decision a resubmitted request answers.
+type ApproverFollowUp struct {
+	// Approver is the approver that asked the questions.
+	Approver string `json:"approver,omitempty"`
+	// Reason is the reason given with the questions.
+	Reason string `json:"reason,omitempty"`
+	// Questions are the questions asked.
+	Questions []string `json:"questions"`
 }
 
 // ApproverDecision is the payload sent back to yaml-mcp-server via webhook.
@@ -94,12 +132,20 @@ type ApproverDecision struct {
 	Metadata map[string]any `json:"metadata,omitempty"`
 	// RequestID is an optional external identifier.
 	RequestID string `json:"request_id,omitempty"`
+	// Token echoes the callback token from the request.
+	Token string `json:"token,omitempty"`
+	// Patch optionally replaces top-level arguments on approve (null removes a field).
+	Patch map[string]any `json:"patch,omitempty"`
+	// Questions are asked back to the model with a needs_info decision.
+	Questions []string `json:"questions,omitempty"`
 }
 
 // ExecutorCallback contains webhook configuration for async executors.
 type ExecutorCallback struct {
 	// URL is the webhook URL for result callbacks.
 	URL string `json:"url"`
+	// Token is a single-use token the result callback must echo back.
+	Token string `json:"token,omitempty"`
 }
 
 // ExecutorTool describes tool metadata for external executors.
@@ -156,14 +202,44 @@ type ExecutorResponse struct {
 type ExecutorDecision struct {
 	// CorrelationID links related requests.
 	CorrelationID string `json:"correlation_id"`
-	// Status is one of success/error.
+	// Status is one of success/error/progress.
 	Status string `json:"status"`
 	// Result provides execution output or error details.
 	Result any `json:"result,omitempty"`
+	// Message describes progress (status progress).
+	Message string `json:"message,omitempty"`
+	// Percent is the completion percentage (status progress, optional).
+	Percent *float64 `json:"percent,omitempty"`
 	// Tool is an optional tool name for observability.
 	Tool string `json:"tool,omitempty"`
 	// Metadata is an optional opaque payload.
 	Metadata map[string]any `json:"metadata,omitempty"`
 	// RequestID is an optional external identifier.
 	RequestID string `json:"request_id,omitempty"`
+	// Token echoes the callback token from the request.
+	Token string `json:"token,omitempty"`
+}
+
+// CancelRequest is the payload sent to cancel_url when a call is cancelled or times out.
+type CancelRequest struct {
+	// CorrelationID links the notice to the cancelled request.
+	CorrelationID string `json:"correlation_id"`
+	// Tool is the tool name.
+	Tool string `json:"tool"`
+	// Reason is one of cancelled/timeout.
+	Reason string `json:"reason"`
+}
+
+// ApproverResult is the payload sent to result_callback_url after the executor finishes.
+type ApproverResult struct {
+	// CorrelationID links the result to the approval request.
+	CorrelationID string `json:"correlation_id"`
+	// Tool is the tool name.
+	Tool string `json:"tool"`
+	// Status is one of success/error/cancelled.
+	Status string `json:"status"`
+	// Summary is the redacted, truncated executor output or error.
+	Summary string `json:"summary,omitempty"`
+	// DurationMs is the execution time in milliseconds.
+	DurationMs int64 `json:"duration_ms"`
 }
@@ -4,10 +4,15 @@ import (
 	"os"
 	"strings"
 	"text/template"
+
+	"filippo.io/age"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/agecrypt"
 )
 
 // FuncMap returns template helpers for YAML rendering.
 func FuncMap(tracker *EnvTracker) template.FuncMap {
+	var identities []age.Identity
 	return template.FuncMap{
 		"env": func(key string) (string, error) {
 			if tracker != nil {
@@ -31,6 +36,16 @@ func FuncMap(tracker *EnvTracker) template.FuncMap {
 			}
 			return def
 		},
+		"decrypt": func(value string) (string, error) {
+			if identities == nil {
+				loaded, err := agecrypt.LoadIdentities()
+				if err != nil {
+					return "", err
+				}
+				identities = loaded
+			}
+			return agecrypt.Decrypt(value, identities...)
+		},
 		"default": func(def, value string) string {
 			if value == "" {
 				return def
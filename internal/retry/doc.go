@@ -0,0 +1,2 @@
+// Package retry retries transient failures with exponential backoff and jitter.
+package retry
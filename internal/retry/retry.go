@@ -0,0 +1,159 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultStatusCodes are retried when a policy does not list status codes.
+var DefaultStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// Policy describes how failed calls are retried.
+type Policy struct {
+	// MaxAttempts is the total number of attempts including the first one.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between attempts.
+	MaxBackoff time.Duration
+	// Multiplier grows the delay after each attempt.
+	Multiplier float64
+	// Jitter randomizes each delay by +/- the given fraction.
+	Jitter float64
+	// StatusCodes lists retryable HTTP status codes (DefaultStatusCodes when empty).
+	StatusCodes []int
+	// ExitCodes lists retryable process exit codes; shell commands are not retried on other codes.
+	ExitCodes []int
+}
+
+// Attempt describes a failed attempt that is about to be retried.
+type Attempt struct {
+	// Number is the failed attempt number, starting at 1.
+	Number int
+	// Err is the attempt error.
+	Err error
+	// Delay is the wait before the next attempt.
+	Delay time.Duration
+}
+
+// StatusError reports a non-2xx HTTP response.
+type StatusError struct {
+	// Code is the HTTP status code.
+	Code int
+	// RetryAfter is the delay requested by the server via Retry-After.
+	RetryAfter time.Duration
+	// Message is the error text.
+	Message string
+}
+
+// Error returns the error message.
+func (e *StatusError) Error() string {
+	return e.Message
+}
+
+// Do calls fn until it succeeds, fails with a non-retryable error or attempts run out.
+// A nil policy calls fn once. Retries never outlive ctx: when the next delay would pass
+// the deadline, the last error is returned immediately.
+func (p *Policy) Do(ctx context.Context, fn func(context.Context) error, onRetry func(Attempt)) error {
+	attempts := 1
+	if p != nil && p.MaxAttempts > 1 {
+		attempts = p.MaxAttempts
+	}
+	for n := 1; ; n++ {
+		err := fn(ctx)
+		if err == nil || n >= attempts || ctx.Err() != nil {
+			return err
+		}
+		retryable, hint := p.classify(err)
+		if !retryable {
+			return err
+		}
+		delay := max(p.backoff(n), hint)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= delay {
+			return err
+		}
+		if onRetry != nil {
+			onRetry(Attempt{Number: n, Err: err, Delay: delay})
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+func (p *Policy) classify(err error) (bool, time.Duration) {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		codes := p.StatusCodes
+		if len(codes) == 0 {
+			codes = DefaultStatusCodes
+		}
+		return slices.Contains(codes, statusErr.Code), statusErr.RetryAfter
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return slices.Contains(p.ExitCodes, exitErr.ExitCode()), 0
+	}
+	if grpcStatus, ok := status.FromError(err); ok {
+		switch grpcStatus.Code() {
+		case codes.Unavailable, codes.ResourceExhausted:
+			return true, 0
+		default:
+			return false, 0
+		}
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true, 0
+	}
+	return false, 0
+}
+
+func (p *Policy) backoff(attempt int) time.Duration {
+	delay := float64(p.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		delay *= p.Multiplier
+	}
+	if p.MaxBackoff > 0 {
+		delay = min(delay, float64(p.MaxBackoff))
+	}
+	if p.Jitter > 0 {
+		delay *= 1 + p.Jitter*(rand.Float64()*2-1)
+	}
+	return time.Duration(delay)
+}
+
+// ParseRetryAfter parses a Retry-After header (seconds or HTTP date).
+func ParseRetryAfter(value string) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0)
+	}
+	return 0
+}
@@ -0,0 +1,149 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os/exec"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPolicyClassify(t *testing.T) {
+	exitErr := exec.Command("sh", "-c", "exit 3").Run()
+	tests := []struct {
+		name      string
+		policy    *Policy
+		err       error
+		retryable bool
+		hint      time.Duration
+	}{
+		{name: "default status code", policy: &Policy{}, err: &StatusError{Code: http.StatusServiceUnavailable}, retryable: true},
+		{name: "status code not in defaults", policy: &Policy{}, err: &StatusError{Code: http.StatusInternalServerError}},
+		{name: "client error", policy: &Policy{}, err: &StatusError{Code: http.StatusBadRequest}},
+		{name: "listed status code", policy: &Policy{StatusCodes: []int{http.StatusInternalServerError}}, err: &StatusError{Code: http.StatusInternalServerError}, retryable: true},
+		{name: "listed codes replace defaults", policy: &Policy{StatusCodes: []int{http.StatusInternalServerError}}, err: &StatusError{Code: http.StatusBadGateway}},
+		{name: "retry-after hint", policy: &Policy{}, err: &StatusError{Code: http.StatusTooManyRequests, RetryAfter: 2 * time.Second}, retryable: true, hint: 2 * time.Second},
+		{name: "wrapped status error", policy: &Policy{}, err: errors.Join(errors.New("call failed"), &StatusError{Code: http.StatusBadGateway}), retryable: true},
+		{name: "exit code without opt-in", policy: &Policy{}, err: exitErr},
+		{name: "listed exit code", policy: &Policy{ExitCodes: []int{3}}, err: exitErr, retryable: true},
+		{name: "other exit code", policy: &Policy{ExitCodes: []int{1}}, err: exitErr},
+		{name: "grpc unavailable", policy: &Policy{}, err: status.Error(codes.Unavailable, "down"), retryable: true},
+		{name: "grpc resource exhausted", policy: &Policy{}, err: status.Error(codes.ResourceExhausted, "busy"), retryable: true},
+		{name: "grpc permission denied", policy: &Policy{}, err: status.Error(codes.PermissionDenied, "no")},
+		{name: "network error", policy: &Policy{}, err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}, retryable: true},
+		{name: "plain error", policy: &Policy{}, err: errors.New("bad input")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			retryable, hint := tt.policy.classify(tt.err)
+			if retryable != tt.retryable || hint != tt.hint {
+				t.Fatalf("classify() = %v, %s; want %v, %s", retryable, hint, tt.retryable, tt.hint)
+			}
+		})
+	}
+}
+
+func TestPolicyDo(t *testing.T) {
+	transient := &StatusError{Code: http.StatusBadGateway}
+	tests := []struct {
+		name      string
+		policy    *Policy
+		timeout   time.Duration
+		errs      []error
+		wantCalls int
+		wantErr   bool
+	}{
+		{name: "nil policy calls once", errs: []error{transient}, wantCalls: 1, wantErr: true},
+		{name: "success on first attempt", policy: &Policy{MaxAttempts: 3}, errs: []error{nil}, wantCalls: 1},
+		{name: "retries until success", policy: &Policy{MaxAttempts: 3}, errs: []error{transient, transient, nil}, wantCalls: 3},
+		{name: "gives up after max attempts", policy: &Policy{MaxAttempts: 2}, errs: []error{transient, transient, nil}, wantCalls: 2, wantErr: true},
+		{name: "stops on non-retryable error", policy: &Policy{MaxAttempts: 3}, errs: []error{&StatusError{Code: http.StatusBadRequest}}, wantCalls: 1, wantErr: true},
+		{name: "delay past deadline", policy: &Policy{MaxAttempts: 3, InitialBackoff: time.Hour}, timeout: time.Minute, errs: []error{transient, nil}, wantCalls: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+			calls, retries := 0, 0
+			err := tt.policy.Do(ctx, func(context.Context) error {
+				err := tt.errs[calls]
+				calls++
+				return err
+			}, func(Attempt) { retries++ })
+			if calls != tt.wantCalls {
+				t.Fatalf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+			if retries != calls-1 {
+				t.Fatalf("onRetry called %d times for %d calls", retries, calls)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Do() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPolicyBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  Policy
+		attempt int
+		want    time.Duration
+	}{
+		{name: "first retry", policy: Policy{InitialBackoff: 100 * time.Millisecond, Multiplier: 2}, attempt: 1, want: 100 * time.Millisecond},
+		{name: "grows by multiplier", policy: Policy{InitialBackoff: 100 * time.Millisecond, Multiplier: 2}, attempt: 3, want: 400 * time.Millisecond},
+		{name: "capped by max backoff", policy: Policy{InitialBackoff: 100 * time.Millisecond, Multiplier: 2, MaxBackoff: 250 * time.Millisecond}, attempt: 3, want: 250 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.backoff(tt.attempt); got != tt.want {
+				t.Fatalf("backoff() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicyBackoffJitter(t *testing.T) {
+	policy := Policy{InitialBackoff: time.Second, Multiplier: 1, Jitter: 0.2}
+	for range 100 {
+		got := policy.backoff(1)
+		if got < 800*time.Millisecond || got > 1200*time.Millisecond {
+			t.Fatalf("backoff() = %s, want within 20%% of 1s", got)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		min   time.Duration
+		max   time.Duration
+	}{
+		{name: "seconds", value: "5", min: 5 * time.Second, max: 5 * time.Second},
+		{name: "surrounding spaces", value: " 5 ", min: 5 * time.Second, max: 5 * time.Second},
+		{name: "http date", value: time.Now().Add(time.Minute).UTC().Format(http.TimeFormat), min: 58 * time.Second, max: time.Minute},
+		{name: "past http date", value: time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)},
+		{name: "empty", value: ""},
+		{name: "zero", value: "0"},
+		{name: "negative", value: "-3"},
+		{name: "garbage", value: "soon"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseRetryAfter(tt.value)
+			if got < tt.min || got > tt.max {
+				t.Fatalf("ParseRetryAfter(%q) = %s, want between %s and %s", tt.value, got, tt.min, tt.max)
+			}
+		})
+	}
+}
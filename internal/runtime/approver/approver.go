@@ -1,6 +1,13 @@
 package approver
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
 
 // Request defines the input sent to approvers.
 type Request struct {
@@ -10,6 +17,36 @@ type Request struct {
 	Arguments map[string]any
 	// CorrelationID links related approvals.
 	CorrelationID string
+	// Diff is an optional preview of the changes the executor will make.
+	Diff string
+	// Caller describes the MCP client that issued the call.
+	Caller Caller
+	// FollowUp is set when the call answers questions asked earlier for the same correlation ID.
+	FollowUp *FollowUp
+	// Metadata is the combined metadata returned by earlier approvers in the chain.
+	Metadata map[string]any
+	// Session is the MCP session that issued the call, if any.
+	Session *mcp.ServerSession
+}
+
+// FollowUp describes the needs_info decision a resubmitted request answers.
+type FollowUp struct {
+	// Source is the approver that asked.
+	Source string
+	// Reason is the reason given with the questions.
+	Reason string
+	// Questions are the questions asked.
+	Questions []string
+}
+
+// Caller describes the MCP client that issued the call.
+type Caller struct {
+	// Name is the client name from initialize.
+	Name string
+	// Version is the client version from initialize.
+	Version string
+	// SessionID is the MCP session identifier (empty for stdio).
+	SessionID string
 }
 
 // Decision represents the approver decision.
@@ -20,6 +57,29 @@ type Decision struct {
 	Reason string
 	// Source identifies the approver.
 	Source string
+	// Patch replaces top-level arguments when the request is allowed (nil values remove fields).
+	Patch map[string]any
+	// NeedsInfo marks a denial that asks the model the Questions before it resubmits.
+	NeedsInfo bool
+	// Questions are the approver's questions (with NeedsInfo).
+	Questions []string
+	// Metadata is opaque data for audit and subsequent approvers.
+	Metadata map[string]any
+}
+
+// NeedsInfo builds a denial asking the model questions; without questions it is a plain denial.
+func NeedsInfo(reason string, questions []string, source string) Decision {
+	if strings.TrimSpace(reason) == "" {
+		reason = "more information requested"
+	}
+	decision := Decision{Allowed: false, Reason: reason, Source: source}
+	for _, question := range questions {
+		if question = strings.TrimSpace(question); question != "" {
+			decision.Questions = append(decision.Questions, question)
+		}
+	}
+	decision.NeedsInfo = len(decision.Questions) > 0
+	return decision
 }
 
 // Approver checks whether an action is allowed.
@@ -34,21 +94,69 @@ type Approver interface {
 type Chain struct {
 	// Approvers is the ordered list to execute.
 	Approvers []Approver
+	// RequireApproval denies a request that every approver skipped; otherwise it is approved.
+	RequireApproval bool
+	// Validate checks arguments after an argument patch (optional).
+	Validate func(args map[string]any) error
+	// OnPatch is called when an approver patches the arguments (optional).
+	OnPatch func(ctx context.Context, req Request, decision Decision)
+	// OnMetadata is called when an approver returns metadata (optional).
+	OnMetadata func(ctx context.Context, req Request, decision Decision)
 }
 
-// Approve executes all approvers in order.
+// Approve executes all approvers in order, skipping conditional approvers that do not apply;
+// an approval lists the approvers that ran as its Source and carries their combined Patch.
+// Patches are applied and metadata is merged before the next approver runs.
 func (c Chain) Approve(ctx context.Context, req Request) (Decision, error) {
+	var (
+		approved []string
+		patch    map[string]any
+	)
 	for _, item := range c.Approvers {
+		if !applies(ctx, item, req) {
+			continue
+		}
+		approved = append(approved, item.Name())
 		decision, err := item.Approve(ctx, req)
 		if err != nil {
 			return Decision{Allowed: false, Reason: err.Error(), Source: item.Name()}, err
 		}
+		if decision.Source == "" {
+			decision.Source = item.Name()
+		}
+		if len(decision.Metadata) > 0 && c.OnMetadata != nil {
+			c.OnMetadata(ctx, req, decision)
+		}
 		if !decision.Allowed {
-			if decision.Source == "" {
-				decision.Source = item.Name()
-			}
 			return decision, nil
 		}
+		if len(decision.Metadata) > 0 {
+			merged := make(map[string]any, len(req.Metadata)+len(decision.Metadata))
+			maps.Copy(merged, req.Metadata)
+			maps.Copy(merged, decision.Metadata)
+			req.Metadata = merged
+		}
+		if len(decision.Patch) == 0 {
+			continue
+		}
+		patched := ApplyPatch(req.Arguments, decision.Patch)
+		if c.Validate != nil {
+			if err := c.Validate(patched); err != nil {
+				reason := fmt.Sprintf("argument patch from %s is invalid: %s", decision.Source, err)
+				return Decision{Allowed: false, Reason: reason, Source: decision.Source}, nil
+			}
+		}
+		req.Arguments = patched
+		if patch == nil {
+			patch = make(map[string]any, len(decision.Patch))
+		}
+		maps.Copy(patch, decision.Patch)
+		if c.OnPatch != nil {
+			c.OnPatch(ctx, req, decision)
+		}
+	}
+	if len(approved) == 0 && c.RequireApproval {
+		return Decision{Allowed: false, Reason: "approval required but no approvers apply"}, nil
 	}
-	return Decision{Allowed: true, Reason: "approved"}, nil
+	return Decision{Allowed: true, Reason: "approved", Source: strings.Join(approved, ", "), Patch: patch, Metadata: req.Metadata}, nil
 }
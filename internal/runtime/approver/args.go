@@ -0,0 +1,32 @@
+package approver
+
+import "strings"
+
+// MetaArgs are the approval and call-tracking arguments a model sends next to the tool input;
+// they describe the call rather than change it.
+var MetaArgs = map[string]bool{
+	"justification":    true,
+	"approval_request": true,
+	"risk_assessment":  true,
+	"links_to_code":    true,
+	"response_format":  true,
+	"correlation_id":   true,
+	"request_id":       true,
+}
+
+// ToolArgs returns a copy of args without MetaArgs.
+func ToolArgs(args map[string]any) map[string]any {
+	out := make(map[string]any, len(args))
+	for key, value := range args {
+		if !MetaArgs[key] {
+			out[key] = value
+		}
+	}
+	return out
+}
+
+// StringArg returns a trimmed string argument (empty when missing or not a string).
+func StringArg(args map[string]any, key string) string {
+	value, _ := args[key].(string)
+	return strings.TrimSpace(value)
+}
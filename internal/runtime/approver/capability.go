@@ -0,0 +1,69 @@
+package approver
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Client capabilities approvers can require.
+const (
+	CapabilityElicitation = "elicitation"
+	CapabilitySampling    = "sampling"
+)
+
+// RequireCapability runs Inner when the calling MCP client advertised Capability and Fallback otherwise.
+type RequireCapability struct {
+	// Capability is the client capability Inner needs.
+	Capability string
+	// Inner is the wrapped approver.
+	Inner Approver
+	// Fallback decides for clients without the capability (deny when nil).
+	Fallback Approver
+	// OnFallback is called before the request is passed to Fallback (optional).
+	OnFallback func(ctx context.Context, req Request, name string)
+}
+
+// Name returns the inner approver name.
+func (r RequireCapability) Name() string {
+	if r.Inner != nil {
+		return r.Inner.Name()
+	}
+	return r.Capability
+}
+
+// Approve passes the request to Inner or, when the client lacks the capability, to Fallback.
+func (r RequireCapability) Approve(ctx context.Context, req Request) (Decision, error) {
+	if r.Inner == nil {
+		return Decision{Allowed: false, Reason: "invalid capability approver", Source: r.Name()}, nil
+	}
+	if ClientSupports(req.Session, r.Capability) {
+		return r.Inner.Approve(ctx, req)
+	}
+	if r.Fallback == nil {
+		return Decision{Allowed: false, Reason: "mcp client does not support " + r.Capability, Source: r.Name()}, nil
+	}
+	if r.OnFallback != nil {
+		r.OnFallback(ctx, req, r.Fallback.Name())
+	}
+	return r.Fallback.Approve(ctx, req)
+}
+
+// ClientSupports reports whether the client behind session advertised capability.
+func ClientSupports(session *mcp.ServerSession, capability string) bool {
+	if session == nil {
+		return false
+	}
+	params := session.InitializeParams()
+	if params == nil || params.Capabilities == nil {
+		return false
+	}
+	switch capability {
+	case CapabilityElicitation:
+		return params.Capabilities.Elicitation != nil
+	case CapabilitySampling:
+		return params.Capabilities.Sampling != nil
+	default:
+		return false
+	}
+}
@@ -0,0 +1,58 @@
+package approver
+
+import "context"
+
+// Conditional runs Inner only when When reports true; Chain and Quorum skip it otherwise.
+type Conditional struct {
+	// Inner is the wrapped approver.
+	Inner Approver
+	// When reports whether the approver applies to the request.
+	When func(req Request) (bool, error)
+	// OnSkip is called when the approver is skipped (optional).
+	OnSkip func(ctx context.Context, req Request, name string)
+	// OnError is called when When fails; the approver then runs (optional).
+	OnError func(ctx context.Context, req Request, name string, err error)
+}
+
+// Name returns the inner approver name.
+func (c Conditional) Name() string {
+	if c.Inner != nil {
+		return c.Inner.Name()
+	}
+	return "conditional"
+}
+
+// Approve executes the inner approver without checking When.
+func (c Conditional) Approve(ctx context.Context, req Request) (Decision, error) {
+	if c.Inner == nil {
+		return Decision{Allowed: false, Reason: "invalid conditional approver", Source: c.Name()}, nil
+	}
+	return c.Inner.Approve(ctx, req)
+}
+
+// Applies evaluates When; evaluation errors fail closed, so the approver runs.
+func (c Conditional) Applies(ctx context.Context, req Request) bool {
+	if c.When == nil {
+		return true
+	}
+	ok, err := c.When(req)
+	if err != nil {
+		if c.OnError != nil {
+			c.OnError(ctx, req, c.Name(), err)
+		}
+		return true
+	}
+	if !ok && c.OnSkip != nil {
+		c.OnSkip(ctx, req, c.Name())
+	}
+	return ok
+}
+
+// applies reports whether item should run for req.
+func applies(ctx context.Context, item Approver, req Request) bool {
+	conditional, ok := item.(Conditional)
+	if !ok {
+		return true
+	}
+	return conditional.Applies(ctx, req)
+}
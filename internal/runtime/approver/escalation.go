@@ -0,0 +1,140 @@
+package approver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// EscalationLevel is one approver of an escalation chain.
+type EscalationLevel struct {
+	// Approver is asked when the level starts.
+	Approver Approver
+	// After is how long the previous level waits before this one starts (ignored for the first level).
+	After time.Duration
+	// Timeout limits how long the level may answer (unlimited when zero).
+	Timeout time.Duration
+}
+
+// Escalation asks its levels one after another while earlier ones can still answer; the first decision wins.
+type Escalation struct {
+	// Label is a human-friendly name.
+	Label string
+	// Levels are the primary approver followed by its escalations.
+	Levels []EscalationLevel
+	// OnEscalate is called when the request is sent to the next level (optional).
+	OnEscalate func(ctx context.Context, req Request, name, reason string)
+	// OnDecision is called with the winning decision (optional).
+	OnDecision func(ctx context.Context, req Request, decision Decision)
+}
+
+type escalationResult struct {
+	index    int
+	decision Decision
+	answered bool
+}
+
+// Name returns approver name for audit and logging.
+func (e Escalation) Name() string {
+	if e.Label != "" {
+		return e.Label
+	}
+	if len(e.Levels) > 0 && e.Levels[0].Approver != nil {
+		return e.Levels[0].Approver.Name()
+	}
+	return "escalation"
+}
+
+// Approve starts the first level and escalates after each level's wait or as soon as every started level
+// gave up without a decision (timeout, error or skipped).
+func (e Escalation) Approve(ctx context.Context, req Request) (Decision, error) {
+	if len(e.Levels) == 0 {
+		return Decision{Allowed: false, Reason: "invalid escalation approver", Source: e.Name()}, nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan escalationResult, len(e.Levels))
+	path := []string{e.Levels[0].Approver.Name()}
+	e.start(ctx, req, 0, results)
+	running, next := 1, 1
+	var timer <-chan time.Time
+	if next < len(e.Levels) {
+		timer = time.After(e.Levels[next].After)
+	}
+	escalate := func(reason string) {
+		level := e.Levels[next]
+		if e.OnEscalate != nil {
+			e.OnEscalate(ctx, req, level.Approver.Name(), reason)
+		}
+		path = append(path, level.Approver.Name())
+		e.start(ctx, req, next, results)
+		running++
+		next++
+		timer = nil
+		if next < len(e.Levels) {
+			timer = time.After(e.Levels[next].After)
+		}
+	}
+
+	for {
+		select {
+		case result := <-results:
+			running--
+			if result.answered {
+				decision := result.decision
+				if len(path) > 1 {
+					decision.Reason = fmt.Sprintf("%s (escalation: %s)", decision.Reason, strings.Join(path, " → "))
+				}
+				if e.OnDecision != nil {
+					e.OnDecision(ctx, req, decision)
+				}
+				return decision, nil
+			}
+			if running > 0 {
+				continue
+			}
+			reason := fmt.Sprintf("%s did not answer: %s", e.Levels[result.index].Approver.Name(), result.decision.Reason)
+			if next >= len(e.Levels) {
+				return Decision{Allowed: false, Reason: "no approver answered (escalation: " + strings.Join(path, " → ") + ")", Source: e.Name()}, nil
+			}
+			escalate(reason)
+		case <-timer:
+			escalate(fmt.Sprintf("no answer within %s", e.Levels[next].After))
+		case <-ctx.Done():
+			return Decision{Allowed: false, Reason: "approval cancelled (escalation: " + strings.Join(path, " → ") + ")", Source: e.Name()}, ctx.Err()
+		}
+	}
+}
+
+// start runs the index-th level; escalation levels get derived correlation ids so async requests do not collide.
+func (e Escalation) start(ctx context.Context, req Request, index int, results chan<- escalationResult) {
+	level := e.Levels[index]
+	if index > 0 {
+		req.CorrelationID = ChildCorrelationID(req.CorrelationID, index)
+	}
+	if !applies(ctx, level.Approver, req) {
+		results <- escalationResult{index: index, decision: Decision{Reason: "skipped"}}
+		return
+	}
+	go func() {
+		levelCtx, cancel := ctx, context.CancelFunc(func() {})
+		if level.Timeout > 0 {
+			levelCtx, cancel = context.WithTimeout(ctx, level.Timeout)
+		}
+		defer cancel()
+		decision, err := level.Approver.Approve(levelCtx, req)
+		switch {
+		case levelCtx.Err() != nil:
+			results <- escalationResult{index: index, decision: Decision{Reason: "approval timeout"}}
+		case err != nil:
+			results <- escalationResult{index: index, decision: Decision{Reason: err.Error()}}
+		default:
+			if decision.Source == "" {
+				decision.Source = level.Approver.Name()
+			}
+			results <- escalationResult{index: index, decision: decision, answered: true}
+		}
+	}()
+}
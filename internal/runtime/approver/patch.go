@@ -0,0 +1,49 @@
+package approver
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+)
+
+// ApplyPatch returns a copy of args with patch applied: values replace top-level fields and nil removes them.
+func ApplyPatch(args, patch map[string]any) map[string]any {
+	out := make(map[string]any, len(args)+len(patch))
+	maps.Copy(out, args)
+	for key, value := range patch {
+		if value == nil {
+			delete(out, key)
+			continue
+		}
+		out[key] = value
+	}
+	return out
+}
+
+// CheckPatch rejects patches that touch fields outside allowed.
+func CheckPatch(patch map[string]any, allowed []string) error {
+	var denied []string
+	for key := range patch {
+		if !slices.Contains(allowed, key) {
+			denied = append(denied, key)
+		}
+	}
+	if len(denied) == 0 {
+		return nil
+	}
+	sort.Strings(denied)
+	return fmt.Errorf("argument patch touches fields that are not patchable: %v", denied)
+}
+
+// LimitPatch turns an approval whose patch is not allowed into a denial; denials drop their patch.
+func LimitPatch(decision Decision, allowed []string) Decision {
+	if !decision.Allowed {
+		decision.Patch = nil
+		return decision
+	}
+	if err := CheckPatch(decision.Patch, allowed); err != nil {
+		return Decision{Allowed: false, Reason: err.Error(), Source: decision.Source}
+	}
+	return decision
+}
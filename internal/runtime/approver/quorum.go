@@ -0,0 +1,188 @@
+package approver
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+)
+
+// Quorum runs child approvers concurrently and allows the request once Required of them approve.
+type Quorum struct {
+	// Label is a human-friendly name.
+	Label string
+	// Required is the number of approvals needed (all applicable children when zero).
+	Required int
+	// Approvers are the children; outstanding ones are cancelled once the outcome is known.
+	Approvers []Approver
+	// AllowEmpty approves when every child is skipped; otherwise such a group denies.
+	AllowEmpty bool
+	// OnDecision is called for each child decision (optional).
+	OnDecision func(ctx context.Context, req Request, decision Decision)
+}
+
+type quorumResult struct {
+	index    int
+	decision Decision
+}
+
+// Name returns approver name for audit and logging.
+func (q Quorum) Name() string {
+	if q.Label != "" {
+		return q.Label
+	}
+	if q.Required <= 0 {
+		return "all_of"
+	}
+	return fmt.Sprintf("%d_of_%d", q.Required, len(q.Approvers))
+}
+
+// ChildCorrelationID derives the correlation id sent to the index-th child, so async children do not collide.
+func ChildCorrelationID(correlationID string, index int) string {
+	return fmt.Sprintf("%s.%d", correlationID, index+1)
+}
+
+// Approve runs all applicable children and combines their decisions; skipped children do not count.
+// A group whose children are all skipped denies unless AllowEmpty is set.
+func (q Quorum) Approve(ctx context.Context, req Request) (Decision, error) {
+	if len(q.Approvers) == 0 || q.Required > len(q.Approvers) {
+		return Decision{Allowed: false, Reason: "invalid quorum approver", Source: q.Name()}, nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	states := make([]childState, len(q.Approvers))
+	results := make(chan quorumResult, len(q.Approvers))
+	total := 0
+	for i, item := range q.Approvers {
+		childReq := req
+		childReq.CorrelationID = ChildCorrelationID(req.CorrelationID, i)
+		if !applies(ctx, item, childReq) {
+			states[i].skipped = true
+			continue
+		}
+		total++
+		go func() {
+			decision, err := item.Approve(ctx, childReq)
+			if err != nil {
+				decision = Decision{Allowed: false, Reason: err.Error()}
+			}
+			if decision.Source == "" {
+				decision.Source = item.Name()
+			}
+			results <- quorumResult{index: i, decision: decision}
+		}()
+	}
+	if total == 0 {
+		reason := "no approvers apply: " + summarize(q.Approvers, states)
+		return Decision{Allowed: q.AllowEmpty, Reason: reason, Source: q.Name()}, nil
+	}
+	required := q.Required
+	if required <= 0 {
+		required = total
+	}
+	if required > total {
+		reason := fmt.Sprintf("only %d of %d approvers apply (need %d)", total, len(q.Approvers), required)
+		return Decision{Allowed: false, Reason: reason, Source: q.Name()}, nil
+	}
+
+	approved, denied := 0, 0
+	var patch, metadata map[string]any
+	for approved < required && denied <= total-required {
+		var result quorumResult
+		select {
+		case result = <-results:
+		case <-ctx.Done():
+			return Decision{Allowed: false, Reason: "approval cancelled: " + summarize(q.Approvers, states), Source: q.Name()}, ctx.Err()
+		}
+		states[result.index].decision = &result.decision
+		if result.decision.Allowed {
+			approved++
+			merged, err := mergePatch(patch, result.decision.Patch)
+			if err != nil {
+				reason := fmt.Sprintf("%s: %s", err, summarize(q.Approvers, states))
+				return Decision{Allowed: false, Reason: reason, Source: q.Name()}, nil
+			}
+			patch = merged
+			if len(result.decision.Metadata) > 0 {
+				if metadata == nil {
+					metadata = make(map[string]any, len(result.decision.Metadata))
+				}
+				maps.Copy(metadata, result.decision.Metadata)
+			}
+		} else {
+			denied++
+		}
+		if q.OnDecision != nil {
+			q.OnDecision(ctx, req, result.decision)
+		}
+	}
+
+	allowed := approved >= required
+	reason := fmt.Sprintf("%d/%d approvals (need %d)", approved, total, required)
+	if summary := summarize(q.Approvers, states); summary != "" {
+		reason += ": " + summary
+	}
+	if !allowed {
+		var questions []string
+		for _, state := range states {
+			if state.decision != nil && state.decision.NeedsInfo {
+				questions = append(questions, state.decision.Questions...)
+			}
+		}
+		return Decision{Allowed: false, Reason: reason, Source: q.Name(), NeedsInfo: len(questions) > 0, Questions: questions}, nil
+	}
+	return Decision{Allowed: true, Reason: reason, Source: q.Name(), Patch: patch, Metadata: metadata}, nil
+}
+
+// mergePatch combines argument patches of approving children; differing values for a field conflict.
+func mergePatch(into, patch map[string]any) (map[string]any, error) {
+	for key, value := range patch {
+		if existing, ok := into[key]; ok && !reflect.DeepEqual(existing, value) {
+			return nil, fmt.Errorf("conflicting argument patches for %s", key)
+		}
+		if into == nil {
+			into = make(map[string]any, len(patch))
+		}
+		into[key] = value
+	}
+	return into, nil
+}
+
+// childState tracks one child of a running quorum.
+type childState struct {
+	skipped  bool
+	decision *Decision
+}
+
+// summarize lists children as "approved by ...; denied by ...; cancelled ...; skipped ...".
+func summarize(items []Approver, states []childState) string {
+	var approved, denied, pending, skipped []string
+	for i, state := range states {
+		switch {
+		case state.skipped:
+			skipped = append(skipped, items[i].Name())
+		case state.decision == nil:
+			pending = append(pending, items[i].Name())
+		case state.decision.Allowed:
+			approved = append(approved, state.decision.Source)
+		default:
+			denied = append(denied, fmt.Sprintf("%s (%s)", state.decision.Source, state.decision.Reason))
+		}
+	}
+	var parts []string
+	if len(approved) > 0 {
+		parts = append(parts, "approved by "+strings.Join(approved, ", "))
+	}
+	if len(denied) > 0 {
+		parts = append(parts, "denied by "+strings.Join(denied, ", "))
+	}
+	if len(pending) > 0 {
+		parts = append(parts, "cancelled "+strings.Join(pending, ", "))
+	}
+	if len(skipped) > 0 {
+		parts = append(parts, "skipped "+strings.Join(skipped, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
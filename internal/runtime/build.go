@@ -2,26 +2,41 @@ package runtime
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"strings"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/grpc"
 
+	yamlmcpv1 "github.com/codex-k8s/yaml-mcp-server/api/yamlmcp/v1"
+	"github.com/codex-k8s/yaml-mcp-server/internal/approver/elicitation"
+	approvergrpc "github.com/codex-k8s/yaml-mcp-server/internal/approver/grpc"
 	approverhttp "github.com/codex-k8s/yaml-mcp-server/internal/approver/http"
 	"github.com/codex-k8s/yaml-mcp-server/internal/approver/limits"
+	approverplugin "github.com/codex-k8s/yaml-mcp-server/internal/approver/plugin"
+	"github.com/codex-k8s/yaml-mcp-server/internal/approver/sampling"
+	"github.com/codex-k8s/yaml-mcp-server/internal/approver/schedule"
 	"github.com/codex-k8s/yaml-mcp-server/internal/approver/shell"
 	"github.com/codex-k8s/yaml-mcp-server/internal/audit"
+	"github.com/codex-k8s/yaml-mcp-server/internal/breaker"
 	"github.com/codex-k8s/yaml-mcp-server/internal/constants"
 	"github.com/codex-k8s/yaml-mcp-server/internal/dsl"
+	"github.com/codex-k8s/yaml-mcp-server/internal/grants"
+	"github.com/codex-k8s/yaml-mcp-server/internal/grpcclient"
 	"github.com/codex-k8s/yaml-mcp-server/internal/idempotency"
+	"github.com/codex-k8s/yaml-mcp-server/internal/operations"
+	"github.com/codex-k8s/yaml-mcp-server/internal/plugin"
 	"github.com/codex-k8s/yaml-mcp-server/internal/protocol"
 	"github.com/codex-k8s/yaml-mcp-server/internal/runtime/approver"
 	"github.com/codex-k8s/yaml-mcp-server/internal/runtime/executor"
+	"github.com/codex-k8s/yaml-mcp-server/internal/secrets"
 	"github.com/codex-k8s/yaml-mcp-server/internal/templates"
 	"github.com/codex-k8s/yaml-mcp-server/internal/timeutil"
+	"github.com/codex-k8s/yaml-mcp-server/internal/timewindow"
+	"github.com/codex-k8s/yaml-mcp-server/internal/tlsutil"
 )
 
 // Builder constructs an MCP server from the DSL config.
@@ -46,6 +61,21 @@ type Builder struct {
 	HTTPApprovals *approverhttp.PendingStore
 	// HTTPExecutions stores pending async executions.
 	HTTPExecutions *executor.PendingStore
+	// Plugins manages long-lived plugin processes.
+	Plugins *plugin.Registry
+	// GRPC shares gRPC client connections.
+	GRPC *grpcclient.Pool
+	// Secrets resolves secret references and redacts their values (built from config when nil).
+	Secrets *secrets.Store
+	// Breakers shares circuit breakers between HTTP approvers and executors.
+	Breakers *breaker.Registry
+	// Operations tracks deferred tool calls (created when a tool uses async_response: deferred).
+	Operations *operations.Store
+	// Grants keeps reusable approvals (created when a tool uses approval_grant).
+	Grants *grants.Store
+
+	httpClients map[string]http.RoundTripper
+	followUps   *followUps
 }
 
 // Build creates an MCP server with tools and resources.
@@ -55,6 +85,20 @@ func (b Builder) Build(cfg *dsl.Config) (*mcp.Server, error) {
 		Version: cfg.Server.Version,
 	}, nil)
 
+	if b.Secrets == nil && len(cfg.Secrets) > 0 {
+		store, err := buildSecrets(cfg.Secrets)
+		if err != nil {
+			return nil, err
+		}
+		b.Secrets = store
+	}
+	httpClients, err := buildHTTPClients(cfg.HTTPClients, b.Secrets)
+	if err != nil {
+		return nil, err
+	}
+	b.httpClients = httpClients
+	b.followUps = newFollowUps()
+
 	for _, res := range cfg.Resources {
 		resource := res
 		server.AddResource(&mcp.Resource{
@@ -71,11 +115,22 @@ func (b Builder) Build(cfg *dsl.Config) (*mcp.Server, error) {
 		})
 	}
 
+	for _, tool := range cfg.Tools {
+		if isDeferred(tool) && b.Operations == nil {
+			b.Operations = operations.NewStore(0)
+		}
+		if tool.ApprovalGrant != nil && b.Grants == nil {
+			b.Grants = grants.NewStore()
+		}
+	}
 	for _, tool := range cfg.Tools {
 		if err := b.addTool(server, tool); err != nil {
 			return nil, err
 		}
 	}
+	if b.Operations != nil {
+		b.addOperationStatusTool(server)
+	}
 
 	return server, nil
 }
@@ -85,11 +140,15 @@ func (b Builder) addTool(server *mcp.Server, tool dsl.ToolConfig) error {
 	if err != nil {
 		return fmt.Errorf("tool %s: %w", tool.Name, err)
 	}
+	if tool.Executor.Retry != nil {
+		exec = executor.Retry{Inner: exec, Policy: retryPolicy(tool.Executor.Retry), OnRetry: b.recordExecutorRetry}
+	}
 
-	chain, err := buildApprovers(tool.Approvers, b.Templates, b)
+	chain, err := buildApprovers(tool, b.Templates, b)
 	if err != nil {
 		return fmt.Errorf("tool %s: %w", tool.Name, err)
 	}
+	granted := grantChain(tool, chain)
 
 	timeout := timeutil.ParseDurationOrDefault(tool.Timeout, 0)
 	if timeout == 0 {
@@ -110,37 +169,7 @@ func (b Builder) addTool(server *mcp.Server, tool dsl.ToolConfig) error {
 		Annotations: buildAnnotations(tool.Annotations),
 	}
 
-	mcp.AddTool(server, mcpTool, func(ctx context.Context, _ *mcp.CallToolRequest, input map[string]any) (*mcp.CallToolResult, protocol.ToolResponse, error) {
-		correlationID, providedID := correlationID(input)
-		args := input
-		format := responseFormat(args)
-		if b.Logger != nil {
-			b.Logger.Info("tool call", "tool", tool.Name, "correlation_id", correlationID, "args", args)
-		}
-		b.recordAudit(ctx, "tool_call", tool.Name, correlationID, "", "")
-
-		cacheKey := ""
-		if b.Cache != nil {
-			key, err := buildCacheKey(tool.Name, correlationID, providedID, args, b.CacheKeyStrategy)
-			if err != nil {
-				if b.Logger != nil {
-					b.Logger.Warn("cache key build failed", "tool", tool.Name, "error", err)
-				}
-			} else {
-				cacheKey = key
-			}
-		}
-		if b.Cache != nil && cacheKey != "" {
-			if cached, ok := b.Cache.Get(cacheKey); ok {
-				cached.CorrelationID = correlationID
-				if b.Logger != nil {
-					b.Logger.Info("tool cache hit", "tool", tool.Name, "correlation_id", correlationID)
-				}
-				b.recordAudit(ctx, "cache_hit", tool.Name, correlationID, cached.Decision, cached.Reason)
-				return nil, cached, nil
-			}
-		}
-
+	run := func(ctx context.Context, session *mcp.ServerSession, progress func(executor.Progress), correlationID, cacheKey string, args map[string]any, format string) protocol.ToolResponse {
 		ctxTool := ctx
 		var cancel context.CancelFunc
 		if timeout > 0 {
@@ -148,6 +177,7 @@ func (b Builder) addTool(server *mcp.Server, tool dsl.ToolConfig) error {
 			defer cancel()
 		}
 
+		ctxTool, approved := withApprovedCallbacks(ctxTool)
 		resp := protocol.ToolResponse{
 			Status:        protocol.StatusSuccess,
 			Decision:      protocol.DecisionApprove,
@@ -161,26 +191,72 @@ func (b Builder) addTool(server *mcp.Server, tool dsl.ToolConfig) error {
 				resp.Decision = protocol.DecisionDeny
 				resp.Reason = "approval required but no approvers configured"
 				applyResponseFormat(format, &resp)
-				return nil, resp, nil
+				return resp
 			}
-			decision, err := chain.Approve(ctxTool, approver.Request{
+			approvalReq := approver.Request{
 				ToolName:      tool.Name,
 				Arguments:     args,
 				CorrelationID: correlationID,
-			})
+				Caller:        callerInfo(session),
+				FollowUp:      b.followUps.take(tool.Name, correlationID),
+				Session:       session,
+			}
+			if previewer, ok := exec.(executor.Previewer); ok {
+				diff, err := previewer.Preview(ctxTool, executor.Request{
+					ToolName:      tool.Name,
+					Arguments:     args,
+					CorrelationID: correlationID,
+					Session:       session,
+				})
+				if err != nil {
+					resp.Status = protocol.StatusError
+					resp.Decision = protocol.DecisionError
+					resp.Reason = fmt.Sprintf("preview failed: %s", err)
+					b.recordAudit(ctx, "preview_error", tool.Name, correlationID, protocol.DecisionError, resp.Reason)
+					applyResponseFormat(format, &resp)
+					return resp
+				}
+				approvalReq.Diff = diff
+			}
+			approvers := chain
+			key, grant, used := b.findGrant(tool, correlationID, args)
+			if used {
+				approvers = granted
+				if len(grant.Patch) > 0 {
+					// Reapply the patch the original approvers made so the grant keeps their constraints.
+					patched := approver.ApplyPatch(args, grant.Patch)
+					if chain.Validate != nil {
+						if err := chain.Validate(patched); err != nil {
+							resp.Status = protocol.StatusDenied
+							resp.Decision = protocol.DecisionDeny
+							resp.Reason = fmt.Sprintf("granted argument patch is invalid: %s", err)
+							b.recordAudit(ctx, "approval_denied", tool.Name, correlationID, protocol.DecisionDeny, resp.Reason)
+							applyResponseFormat(format, &resp)
+							return resp
+						}
+					}
+					approvalReq.Arguments = patched
+				}
+			}
+			decision, err := approvers.Approve(ctxTool, approvalReq)
 			if err != nil {
-				if applyTimeoutResponse(ctxTool, &resp, tool.TimeoutMessage, format) {
-					return nil, resp, nil
+				if b.applyCancelResponse(ctxTool, &resp, tool.Name, tool.TimeoutMessage, format) {
+					return resp
 				}
 				resp.Status = protocol.StatusError
 				resp.Decision = protocol.DecisionError
 				resp.Reason = err.Error()
 				b.recordAudit(ctx, "approval_error", tool.Name, correlationID, protocol.DecisionError, err.Error())
 				applyResponseFormat(format, &resp)
-				return nil, resp, nil
+				return resp
+			}
+			if b.applyCancelResponse(ctxTool, &resp, tool.Name, tool.TimeoutMessage, format) {
+				return resp
 			}
-			if applyTimeoutResponse(ctxTool, &resp, tool.TimeoutMessage, format) {
-				return nil, resp, nil
+			if decision.NeedsInfo {
+				b.applyNeedsInfo(ctx, &resp, tool.Name, decision)
+				applyResponseFormat(format, &resp)
+				return resp
 			}
 			if !decision.Allowed {
 				resp.Status = protocol.StatusDenied
@@ -188,19 +264,52 @@ func (b Builder) addTool(server *mcp.Server, tool dsl.ToolConfig) error {
 				resp.Reason = decision.Reason
 				b.recordAudit(ctx, "approval_denied", tool.Name, correlationID, protocol.DecisionDeny, decision.Reason)
 				applyResponseFormat(format, &resp)
-				return nil, resp, nil
+				return resp
+			}
+			if used && !b.useGrant(ctx, tool, key, correlationID) {
+				resp.Status = protocol.StatusDenied
+				resp.Decision = protocol.DecisionDeny
+				resp.Reason = "approval grant expired or was used up during approval; retry the call"
+				b.recordAudit(ctx, "approval_denied", tool.Name, correlationID, protocol.DecisionDeny, resp.Reason)
+				applyResponseFormat(format, &resp)
+				return resp
 			}
 			b.recordAudit(ctx, "approval_ok", tool.Name, correlationID, protocol.DecisionApprove, decision.Reason)
+			if !used {
+				b.storeGrant(ctx, tool, key, correlationID, decision)
+			}
+			patch := decision.Patch
+			if used {
+				patch = mergeGrantPatch(grant.Patch, decision.Patch)
+			}
+			if len(patch) > 0 {
+				args = approver.ApplyPatch(args, patch)
+				resp.Arguments = args
+				b.recordAudit(ctx, "arguments_effective", tool.Name, correlationID, protocol.DecisionApprove, b.argumentsJSON(args))
+			}
 		}
 
+		started := time.Now()
 		output, err := exec.Execute(ctxTool, executor.Request{
 			ToolName:      tool.Name,
 			Arguments:     args,
 			CorrelationID: correlationID,
+			Session:       session,
+			Progress:      progress,
 		})
+		defer func() {
+			summary := output
+			if err != nil {
+				summary = err.Error()
+				if output != "" {
+					summary = fmt.Sprintf("%s: %s", summary, output)
+				}
+			}
+			b.sendResults(ctx, approved.list(), tool.Name, correlationID, resp.Status, summary, time.Since(started))
+		}()
 		if err != nil {
-			if applyTimeoutResponse(ctxTool, &resp, tool.TimeoutMessage, format) {
-				return nil, resp, nil
+			if b.applyCancelResponse(ctxTool, &resp, tool.Name, tool.TimeoutMessage, format) {
+				return resp
 			}
 			resp.Status = protocol.StatusError
 			resp.Decision = protocol.DecisionError
@@ -210,11 +319,11 @@ func (b Builder) addTool(server *mcp.Server, tool dsl.ToolConfig) error {
 			}
 			b.recordAudit(ctx, "tool_error", tool.Name, correlationID, protocol.DecisionError, resp.Reason)
 			applyResponseFormat(format, &resp)
-			return nil, resp, nil
+			return resp
 		}
 
-		if applyTimeoutResponse(ctxTool, &resp, tool.TimeoutMessage, format) {
-			return nil, resp, nil
+		if b.applyCancelResponse(ctxTool, &resp, tool.Name, tool.TimeoutMessage, format) {
+			return resp
 		}
 
 		resp.Reason = output
@@ -227,7 +336,64 @@ func (b Builder) addTool(server *mcp.Server, tool dsl.ToolConfig) error {
 			}
 			b.recordAudit(ctx, "cache_store", tool.Name, correlationID, resp.Decision, resp.Reason)
 		}
-		return nil, resp, nil
+		return resp
+	}
+
+	deferred := isDeferred(tool)
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input map[string]any) (*mcp.CallToolResult, protocol.ToolResponse, error) {
+		var session *mcp.ServerSession
+		if req != nil {
+			session = req.Session
+		}
+		correlationID, providedID := correlationID(input)
+		args := input
+		format := responseFormat(args)
+		if b.Logger != nil {
+			b.Logger.Info("tool call", "tool", tool.Name, "correlation_id", correlationID, "args", args)
+		}
+		b.recordAudit(ctx, "tool_call", tool.Name, correlationID, "", "")
+
+		cacheKey := ""
+		if b.Cache != nil {
+			key, err := buildCacheKey(tool.Name, correlationID, providedID, args, b.CacheKeyStrategy)
+			if err != nil {
+				if b.Logger != nil {
+					b.Logger.Warn("cache key build failed", "tool", tool.Name, "error", err)
+				}
+			} else {
+				cacheKey = key
+			}
+		}
+		if b.Cache != nil && cacheKey != "" {
+			if cached, ok := b.Cache.Get(cacheKey); ok {
+				cached.CorrelationID = correlationID
+				if b.Logger != nil {
+					b.Logger.Info("tool cache hit", "tool", tool.Name, "correlation_id", correlationID)
+				}
+				b.recordAudit(ctx, "cache_hit", tool.Name, correlationID, cached.Decision, cached.Reason)
+				return nil, cached, nil
+			}
+		}
+
+		if deferred {
+			return nil, b.startOperation(ctx, session, tool, correlationID, func(ctx context.Context) protocol.ToolResponse {
+				// The original request is answered, so progress is only audited.
+				progress := b.progressReporter(ctx, nil, nil, tool.Name, correlationID)
+				return run(ctx, session, progress, correlationID, cacheKey, args, format)
+			}), nil
+		}
+		var progressToken any
+		if req != nil && req.Params != nil {
+			progressToken = req.Params.GetProgressToken()
+		}
+		progress := b.progressReporter(ctx, session, progressToken, tool.Name, correlationID)
+		return nil, run(ctx, session, progress, correlationID, cacheKey, args, format), nil
+	}
+
+	mcp.AddTool(server, mcpTool, func(ctx context.Context, req *mcp.CallToolRequest, input map[string]any) (*mcp.CallToolResult, protocol.ToolResponse, error) {
+		result, resp, err := handler(ctx, req, input)
+		resp.Reason = b.Secrets.Redact(resp.Reason)
+		return result, resp, err
 	})
 
 	return nil
@@ -238,97 +404,350 @@ func buildExecutor(tool dsl.ToolConfig, builder Builder) (executor.Executor, err
 	switch strings.ToLower(strings.TrimSpace(cfg.Type)) {
 	case constants.ExecutorShell:
 		return executor.Shell{
-			Command: cfg.Command,
-			Args:    cfg.Args,
-			Env:     cfg.Env,
+			Command:        cfg.Command,
+			Args:           cfg.Args,
+			Env:            cfg.Env,
+			SecretEnv:      secretRefs(cfg.SecretEnv),
+			Secrets:        builder.Secrets,
+			ProgressPrefix: cfg.ProgressPrefix,
+		}, nil
+	case constants.ExecutorFile:
+		return executor.File{
+			Operation:   cfg.Operation,
+			Path:        cfg.Path,
+			Content:     cfg.Content,
+			Roots:       cfg.Roots,
+			MaxBytes:    cfg.MaxBytes,
+			ClientRoots: cfg.ClientRoots,
 		}, nil
 	case constants.ExecutorHTTP:
 		webhookURL := strings.TrimSpace(cfg.WebhookURL)
 		if webhookURL == "" {
 			webhookURL = strings.TrimSpace(builder.ExecutorWebhookURL)
 		}
+		url, endpoints, err := builder.endpoints(cfg.URL, cfg.URLs, cfg.CircuitBreaker)
+		if err != nil {
+			return nil, err
+		}
+		webhookAuth := webhookVerifier(tool.Name+"/executor", cfg.WebhookAuth, builder.Secrets)
+		if webhookAuth != nil && builder.HTTPExecutions != nil {
+			builder.HTTPExecutions.AddVerifier(webhookAuth)
+		}
 		return executor.HTTP{
-			URL:        cfg.URL,
-			Method:     cfg.Method,
-			Headers:    cfg.Headers,
-			Timeout:    timeutil.ParseDurationOrDefault(cfg.Timeout, 10*time.Second),
-			Async:      cfg.Async,
-			WebhookURL: webhookURL,
-			Pending:    builder.HTTPExecutions,
-			Spec:       cfg.Spec,
-			Tool: protocol.ExecutorTool{
-				Name:        tool.Name,
-				Title:       tool.Title,
-				Description: tool.Description,
-				InputSchema: tool.InputSchema,
-				OutputSchema: func() map[string]any {
-					if len(tool.OutputSchema) == 0 {
-						return nil
-					}
-					return tool.OutputSchema
-				}(),
-				Metadata: tool.Metadata,
-				Tags:     tool.Tags,
-			},
-			Lang:   builder.Lang,
-			Markup: "markdown",
+			URL:           url,
+			Endpoints:     endpoints,
+			Transport:     builder.httpClients[strings.TrimSpace(cfg.HTTPClient)],
+			WebhookAuth:   webhookAuth,
+			Method:        cfg.Method,
+			Headers:       cfg.Headers,
+			Timeout:       timeutil.ParseDurationOrDefault(cfg.Timeout, 10*time.Second),
+			Async:         cfg.Async,
+			WebhookURL:    webhookURL,
+			Pending:       builder.HTTPExecutions,
+			Spec:          cfg.Spec,
+			Tool:          executorTool(tool),
+			Lang:          builder.Lang,
+			Markup:        "markdown",
+			SecretHeaders: secretRefs(cfg.SecretHeaders),
+			Secrets:       builder.Secrets,
+			CancelURL:     strings.TrimSpace(cfg.CancelURL),
+			OnCancel:      builder.cancelNotifier(tool.Name, "executor"),
+		}, nil
+	case constants.ExecutorGRPC:
+		conn, err := builder.grpcConn(cfg.Address, cfg.TLS)
+		if err != nil {
+			return nil, err
+		}
+		return executor.GRPC{
+			Service:       yamlmcpv1.NewExecutorServiceClient(conn),
+			Headers:       cfg.Headers,
+			Timeout:       timeutil.ParseDurationOrDefault(cfg.Timeout, 10*time.Second),
+			Stream:        cfg.Stream,
+			Spec:          cfg.Spec,
+			Tool:          executorTool(tool),
+			Lang:          builder.Lang,
+			Markup:        "markdown",
+			SecretHeaders: secretRefs(cfg.SecretHeaders),
+			Secrets:       builder.Secrets,
+		}, nil
+	case constants.ExecutorPlugin:
+		proc, err := builder.plugin(tool.Name, cfg.Command, cfg.Args, cfg.Env, cfg.PingInterval)
+		if err != nil {
+			return nil, err
+		}
+		return executor.Plugin{
+			Process: proc,
+			Spec:    cfg.Spec,
+			Tool:    executorTool(tool),
+			Lang:    builder.Lang,
+			Markup:  "markdown",
 		}, nil
 	default:
 		return nil, fmt.Errorf("unknown executor type: %s", cfg.Type)
 	}
 }
 
-func buildApprovers(configs []dsl.ApproverConfig, renderer templates.Renderer, builder Builder) (approver.Chain, error) {
+func buildApprovers(tool dsl.ToolConfig, renderer templates.Renderer, builder Builder) (approver.Chain, error) {
+	configs := tool.Approvers
 	if len(configs) == 0 {
 		return approver.Chain{}, nil
 	}
 
-	var items []approver.Approver
-	for _, cfg := range configs {
-		timeout := timeutil.ParseDurationOrDefault(cfg.Timeout, 0)
-		switch cfg.Type {
-		case constants.ApproverHTTP:
-			webhookURL := strings.TrimSpace(cfg.WebhookURL)
-			if webhookURL == "" {
-				webhookURL = strings.TrimSpace(builder.ApprovalWebhookURL)
-			}
-			markup := strings.TrimSpace(cfg.Markup)
-			if markup == "" {
-				markup = "markdown"
-			}
-			client := approverhttp.Client{
-				Label:      cfg.Name,
-				URL:        cfg.URL,
-				Method:     cfg.Method,
-				Headers:    cfg.Headers,
-				Timeout:    timeutil.ParseDurationOrDefault(cfg.Timeout, 10*time.Second),
-				Async:      cfg.Async,
-				Lang:       builder.Lang,
-				Markup:     markup,
-				Pending:    builder.HTTPApprovals,
-				WebhookURL: webhookURL,
-			}
-			items = append(items, wrapTimeout(client, timeout))
-		case constants.ApproverShell:
-			approverItem := shell.Approver{
-				Label:          cfg.Name,
-				Command:        cfg.Command,
-				Args:           cfg.Args,
-				Env:            cfg.Env,
-				AllowExitCodes: cfg.AllowExitCodes,
-			}
-			items = append(items, wrapTimeout(approverItem, timeout))
-		case constants.ApproverLimits:
-			approverItem, err := limits.NewApprover(cfg.Name, cfg.MaxTotal, cfg.RatePerMinute, toFieldPolicies(cfg.FieldPolicies), renderer)
-			if err != nil {
-				return approver.Chain{}, err
-			}
-			items = append(items, wrapTimeout(approverItem, timeout))
-		default:
-			return approver.Chain{}, fmt.Errorf("unknown approver type: %s", cfg.Type)
+	items := make([]approver.Approver, 0, len(configs))
+	for i, cfg := range configs {
+		item, err := buildApprover(tool, fmt.Sprintf("approvers[%d]", i), cfg, renderer, builder)
+		if err != nil {
+			return approver.Chain{}, err
+		}
+		items = append(items, item)
+	}
+	validate, err := argumentsValidator(tool)
+	if err != nil {
+		return approver.Chain{}, err
+	}
+	return approver.Chain{
+		Approvers:       items,
+		RequireApproval: tool.RequiresApproval,
+		Validate:        validate,
+		OnPatch:         builder.recordPatch,
+		OnMetadata:      builder.recordMetadata,
+	}, nil
+}
+
+// buildApprover builds the approver at path (e.g. "approvers[0].approvers[1]") of the tool,
+// as approver.Escalation when it has escalation entries and wrapped in approver.Conditional
+// when it has a when expression.
+func buildApprover(tool dsl.ToolConfig, path string, cfg dsl.ApproverConfig, renderer templates.Renderer, builder Builder) (approver.Approver, error) {
+	build := buildApproverType
+	if len(cfg.Escalation) > 0 {
+		build = buildEscalation
+	}
+	item, err := build(tool, path, cfg, renderer, builder)
+	if err != nil || strings.TrimSpace(cfg.When) == "" {
+		return item, err
+	}
+	return builder.conditional(tool, item, cfg.When)
+}
+
+func buildApproverType(tool dsl.ToolConfig, path string, cfg dsl.ApproverConfig, renderer templates.Renderer, builder Builder) (approver.Approver, error) {
+	toolName := tool.Name
+	timeout := timeutil.ParseDurationOrDefault(cfg.Timeout, 0)
+	switch strings.ToLower(strings.TrimSpace(cfg.Type)) {
+	case constants.ApproverHTTP:
+		webhookURL := strings.TrimSpace(cfg.WebhookURL)
+		if webhookURL == "" {
+			webhookURL = strings.TrimSpace(builder.ApprovalWebhookURL)
+		}
+		markup := strings.TrimSpace(cfg.Markup)
+		if markup == "" {
+			markup = "markdown"
+		}
+		url, endpoints, err := builder.endpoints(cfg.URL, cfg.URLs, cfg.CircuitBreaker)
+		if err != nil {
+			return nil, err
+		}
+		webhookAuth := webhookVerifier(toolName+"/"+path, cfg.WebhookAuth, builder.Secrets)
+		if webhookAuth != nil && builder.HTTPApprovals != nil {
+			builder.HTTPApprovals.AddVerifier(webhookAuth)
+		}
+		client := approverhttp.Client{
+			Label:           cfg.Name,
+			URL:             url,
+			Endpoints:       endpoints,
+			Transport:       builder.httpClients[strings.TrimSpace(cfg.HTTPClient)],
+			WebhookAuth:     webhookAuth,
+			Method:          cfg.Method,
+			Headers:         cfg.Headers,
+			Timeout:         timeutil.ParseDurationOrDefault(cfg.Timeout, 10*time.Second),
+			Async:           cfg.Async,
+			Lang:            builder.Lang,
+			Markup:          markup,
+			Pending:         builder.HTTPApprovals,
+			WebhookURL:      webhookURL,
+			Retry:           retryPolicy(cfg.Retry),
+			OnRetry:         builder.recordApproverRetry,
+			SecretHeaders:   secretRefs(cfg.SecretHeaders),
+			Secrets:         builder.Secrets,
+			CancelURL:       strings.TrimSpace(cfg.CancelURL),
+			OnCancel:        builder.cancelNotifier(toolName, path),
+			PatchableFields: cfg.PatchableFields,
+		}
+		return wrapTimeout(builder.withResultCallback(client, path, cfg), timeout), nil
+	case constants.ApproverShell:
+		approverItem := shell.Approver{
+			Label:          cfg.Name,
+			Command:        cfg.Command,
+			Args:           cfg.Args,
+			Env:            cfg.Env,
+			AllowExitCodes: cfg.AllowExitCodes,
+			Output:         cfg.Output,
+			SecretEnv:      secretRefs(cfg.SecretEnv),
+			Secrets:        builder.Secrets,
+		}
+		return wrapTimeout(approverItem, timeout), nil
+	case constants.ApproverPlugin:
+		proc, err := builder.plugin(cfg.Name, cfg.Command, cfg.Args, cfg.Env, cfg.PingInterval)
+		if err != nil {
+			return nil, err
+		}
+		markup := strings.TrimSpace(cfg.Markup)
+		if markup == "" {
+			markup = "markdown"
+		}
+		approverItem := approverplugin.Approver{
+			Label:           cfg.Name,
+			Process:         proc,
+			Lang:            builder.Lang,
+			Markup:          markup,
+			PatchableFields: cfg.PatchableFields,
+		}
+		return wrapTimeout(approverItem, timeout), nil
+	case constants.ApproverGRPC:
+		conn, err := builder.grpcConn(cfg.Address, cfg.TLS)
+		if err != nil {
+			return nil, err
+		}
+		markup := strings.TrimSpace(cfg.Markup)
+		if markup == "" {
+			markup = "markdown"
+		}
+		client := approvergrpc.Client{
+			Label:         cfg.Name,
+			Service:       yamlmcpv1.NewApproverServiceClient(conn),
+			Headers:       cfg.Headers,
+			Timeout:       timeutil.ParseDurationOrDefault(cfg.Timeout, 10*time.Second),
+			Stream:        cfg.Stream,
+			Lang:          builder.Lang,
+			Markup:        markup,
+			SecretHeaders: secretRefs(cfg.SecretHeaders),
+			Secrets:       builder.Secrets,
+		}
+		return wrapTimeout(builder.withResultCallback(client, path, cfg), timeout), nil
+	case constants.ApproverLimits:
+		approverItem, err := limits.NewApprover(cfg.Name, cfg.MaxTotal, cfg.RatePerMinute, toFieldPolicies(cfg.FieldPolicies), renderer)
+		if err != nil {
+			return nil, err
+		}
+		return wrapTimeout(approverItem, timeout), nil
+	case constants.ApproverPolicy:
+		approverItem, err := NewPolicyApprover(tool, cfg)
+		if err != nil {
+			return nil, err
+		}
+		return wrapTimeout(approverItem, timeout), nil
+	case constants.ApproverSchedule:
+		approverItem, err := newScheduleApprover(cfg, renderer)
+		if err != nil {
+			return nil, err
 		}
+		return wrapTimeout(approverItem, timeout), nil
+	case constants.ApproverElicitation:
+		fallback, err := buildFallback(tool, path, cfg, renderer, builder)
+		if err != nil {
+			return nil, err
+		}
+		return approver.RequireCapability{
+			Capability: approver.CapabilityElicitation,
+			Inner:      elicitation.Approver{Label: cfg.Name, Timeout: timeout, Renderer: renderer},
+			Fallback:   fallback,
+			OnFallback: builder.fallbackRecorder(approver.CapabilityElicitation),
+		}, nil
+	case constants.ApproverSampling:
+		fallback, err := buildFallback(tool, path, cfg, renderer, builder)
+		if err != nil {
+			return nil, err
+		}
+		return approver.RequireCapability{
+			Capability: approver.CapabilitySampling,
+			Inner: sampling.Approver{
+				Label:     cfg.Name,
+				Prompt:    cfg.Prompt,
+				MaxTokens: int64(cfg.MaxTokens),
+				Timeout:   timeout,
+			},
+			Fallback:   fallback,
+			OnFallback: builder.fallbackRecorder(approver.CapabilitySampling),
+		}, nil
+	case constants.ApproverAllOf, constants.ApproverAnyOf, constants.ApproverNOfM:
+		return buildQuorum(tool, path, cfg, renderer, builder)
+	default:
+		return nil, fmt.Errorf("unknown approver type: %s", cfg.Type)
 	}
-	return approver.Chain{Approvers: items}, nil
+}
+
+func executorTool(tool dsl.ToolConfig) protocol.ExecutorTool {
+	out := protocol.ExecutorTool{
+		Name:        tool.Name,
+		Title:       tool.Title,
+		Description: tool.Description,
+		InputSchema: tool.InputSchema,
+		Metadata:    tool.Metadata,
+		Tags:        tool.Tags,
+	}
+	if len(tool.OutputSchema) > 0 {
+		out.OutputSchema = tool.OutputSchema
+	}
+	return out
+}
+
+func (b Builder) plugin(name, command string, args []string, env map[string]string, pingInterval string) (*plugin.Process, error) {
+	if b.Plugins == nil {
+		return nil, fmt.Errorf("plugin registry is not configured")
+	}
+	return b.Plugins.Get(plugin.Config{
+		Name:         name,
+		Command:      command,
+		Args:         args,
+		Env:          env,
+		PingInterval: timeutil.ParseDurationOrDefault(pingInterval, 0),
+	}), nil
+}
+
+// endpoints returns the primary URL and, when failover URLs or a circuit breaker
+// are configured, the endpoint list used by HTTP approvers and executors.
+func (b Builder) endpoints(url string, urls []string, cfg *dsl.CircuitBreakerConfig) (string, *breaker.Endpoints, error) {
+	all := make([]string, 0, len(urls)+1)
+	if strings.TrimSpace(url) != "" {
+		all = append(all, strings.TrimSpace(url))
+	}
+	all = append(all, urls...)
+	if len(all) == 0 {
+		return "", nil, nil
+	}
+	if len(urls) == 0 && cfg == nil {
+		return all[0], nil, nil
+	}
+	breakerCfg := breaker.Config{}
+	if cfg != nil {
+		breakerCfg = breaker.Config{
+			FailureThreshold: cfg.FailureThreshold,
+			OpenDuration:     timeutil.ParseDurationOrDefault(cfg.OpenDuration, 0),
+			HalfOpenProbes:   cfg.HalfOpenProbes,
+		}
+	}
+	endpoints, err := breaker.NewEndpoints(b.Breakers, breakerCfg, all...)
+	if err != nil {
+		return "", nil, err
+	}
+	return all[0], endpoints, nil
+}
+
+func (b Builder) grpcConn(address string, tlsCfg *dsl.TLSConfig) (*grpc.ClientConn, error) {
+	if b.GRPC == nil {
+		return nil, fmt.Errorf("grpc client pool is not configured")
+	}
+	cfg := grpcclient.Config{Address: address}
+	if tlsCfg != nil {
+		cfg.Plaintext = tlsCfg.Disable
+		cfg.TLS = tlsutil.Config{
+			CAFile:             tlsCfg.CAFile,
+			CertFile:           tlsCfg.CertFile,
+			KeyFile:            tlsCfg.KeyFile,
+			ServerName:         tlsCfg.ServerName,
+			InsecureSkipVerify: tlsCfg.InsecureSkipVerify,
+		}
+	}
+	return b.GRPC.Dial(cfg)
 }
 
 func wrapTimeout(item approver.Approver, timeout time.Duration) approver.Approver {
@@ -338,6 +757,35 @@ func wrapTimeout(item approver.Approver, timeout time.Duration) approver.Approve
 	return approver.Timeout{Inner: item, Timeout: timeout}
 }
 
+func newScheduleApprover(cfg dsl.ApproverConfig, renderer templates.Renderer) (*schedule.Approver, error) {
+	loc, err := time.LoadLocation(strings.TrimSpace(cfg.Timezone))
+	if err != nil {
+		return nil, err
+	}
+	approverItem := &schedule.Approver{
+		Label:        cfg.Name,
+		Location:     loc,
+		FreezeFile:   strings.TrimSpace(cfg.FreezeFile),
+		HolidaysFile: strings.TrimSpace(cfg.HolidaysICal),
+		Renderer:     renderer,
+	}
+	for _, window := range cfg.Windows {
+		parsed, err := timewindow.ParseWindow(window.Days, window.From, window.To)
+		if err != nil {
+			return nil, err
+		}
+		approverItem.Windows = append(approverItem.Windows, parsed)
+	}
+	for _, freeze := range cfg.Freezes {
+		parsed, err := timewindow.ParsePeriod(timewindow.PeriodSpec(freeze), loc)
+		if err != nil {
+			return nil, err
+		}
+		approverItem.Freezes = append(approverItem.Freezes, parsed)
+	}
+	return approverItem, nil
+}
+
 func toFieldPolicies(policies map[string]dsl.FieldPolicy) map[string]limits.FieldPolicy {
 	if policies == nil {
 		return nil
@@ -371,21 +819,10 @@ func (b Builder) recordAudit(ctx context.Context, eventType, tool, correlationID
 		Tool:          tool,
 		CorrelationID: correlationID,
 		Decision:      decision,
-		Reason:        reason,
+		Reason:        b.Secrets.Redact(reason),
 	})
 }
 
-func applyTimeoutResponse(ctx context.Context, resp *protocol.ToolResponse, timeoutMsg, format string) bool {
-	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
-		return false
-	}
-	resp.Status = protocol.StatusError
-	resp.Decision = protocol.DecisionError
-	resp.Reason = timeoutMessage(timeoutMsg)
-	applyResponseFormat(format, resp)
-	return true
-}
-
 func buildAnnotations(cfg *dsl.ToolAnnotationsConfig) *mcp.ToolAnnotations {
 	if cfg == nil {
 		return nil
@@ -439,6 +876,9 @@ func applyResponseFormat(format string, resp *protocol.ToolResponse) {
 		if message == "" {
 			message = "no details"
 		}
+		for _, question := range resp.Questions {
+			message += "\n- " + question
+		}
 		resp.Reason = fmt.Sprintf("**status**: %s\n**decision**: %s\n\n%s", resp.Status, resp.Decision, message)
 	default:
 		return
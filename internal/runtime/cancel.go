@@ -0,0 +1,43 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/httpclient"
+	"github.com/codex-k8s/yaml-mcp-server/internal/protocol"
+)
+
+// cancelNotifier audits cancellation notices sent to cancel_url of the named approver or executor.
+func (b Builder) cancelNotifier(toolName, target string) func(ctx context.Context, correlationID string, err error) {
+	return func(ctx context.Context, correlationID string, err error) {
+		reason := target + ": " + httpclient.CancelReason(ctx)
+		if err != nil {
+			reason = target + ": " + b.Secrets.Redact(err.Error())
+			if b.Logger != nil {
+				b.Logger.Warn("cancel notice failed", "tool", toolName, "correlation_id", correlationID, "target", target, "error", reason)
+			}
+		}
+		b.recordAudit(ctx, "cancel_notice", toolName, correlationID, "", reason)
+	}
+}
+
+// applyCancelResponse reports a cancelled call as "cancelled" and a timed-out one as "error";
+// it returns false while ctx is alive.
+func (b Builder) applyCancelResponse(ctx context.Context, resp *protocol.ToolResponse, toolName, timeoutMsg, format string) bool {
+	if ctx.Err() == nil {
+		return false
+	}
+	resp.Decision = protocol.DecisionError
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		resp.Status = protocol.StatusError
+		resp.Reason = timeoutMessage(timeoutMsg)
+		b.recordAudit(ctx, "timeout", toolName, resp.CorrelationID, protocol.DecisionError, resp.Reason)
+	} else {
+		resp.Status = protocol.StatusCancelled
+		resp.Reason = "cancelled by client"
+		b.recordAudit(ctx, "cancelled", toolName, resp.CorrelationID, protocol.DecisionError, resp.Reason)
+	}
+	applyResponseFormat(format, resp)
+	return true
+}
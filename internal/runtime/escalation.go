@@ -0,0 +1,54 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/dsl"
+	"github.com/codex-k8s/yaml-mcp-server/internal/protocol"
+	"github.com/codex-k8s/yaml-mcp-server/internal/runtime/approver"
+	"github.com/codex-k8s/yaml-mcp-server/internal/templates"
+	"github.com/codex-k8s/yaml-mcp-server/internal/timeutil"
+)
+
+// buildEscalation builds an HTTP approver with its escalation entries; each level keeps its own timeout.
+func buildEscalation(tool dsl.ToolConfig, path string, cfg dsl.ApproverConfig, renderer templates.Renderer, builder Builder) (approver.Approver, error) {
+	primary, err := buildApproverType(tool, path, cfg, renderer, builder)
+	if err != nil {
+		return nil, err
+	}
+	levels := []approver.EscalationLevel{escalationLevel(primary, 0)}
+	for k, entryCfg := range cfg.Escalation {
+		entry, err := buildApprover(tool, fmt.Sprintf("%s.escalation[%d]", path, k), entryCfg, renderer, builder)
+		if err != nil {
+			return nil, err
+		}
+		levels = append(levels, escalationLevel(entry, timeutil.ParseDurationOrDefault(entryCfg.After, 0)))
+	}
+	return approver.Escalation{
+		Label:      cfg.Name,
+		Levels:     levels,
+		OnEscalate: builder.recordEscalation,
+		OnDecision: builder.recordApproverDecision,
+	}, nil
+}
+
+// escalationLevel moves the approver timeout to the level, so a timeout escalates instead of denying.
+func escalationLevel(item approver.Approver, after time.Duration) approver.EscalationLevel {
+	level := approver.EscalationLevel{Approver: item, After: after}
+	switch typed := item.(type) {
+	case approver.Timeout:
+		level.Approver, level.Timeout = typed.Inner, typed.Timeout
+	case approver.Conditional:
+		if inner, ok := typed.Inner.(approver.Timeout); ok {
+			typed.Inner = inner.Inner
+			level.Approver, level.Timeout = typed, inner.Timeout
+		}
+	}
+	return level
+}
+
+func (b Builder) recordEscalation(ctx context.Context, req approver.Request, name, reason string) {
+	b.recordAudit(ctx, "approver_escalated", req.ToolName, req.CorrelationID, protocol.DecisionPending, "escalated to "+name+": "+reason)
+}
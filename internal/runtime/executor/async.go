@@ -3,68 +3,28 @@ package executor
 import (
 	"encoding/json"
 	"errors"
+	"io"
 	"log/slog"
 	"net/http"
 	"strings"
-	"sync"
+	"time"
 
-	"github.com/codex-k8s/yaml-mcp-server/internal/maputil"
+	"github.com/codex-k8s/yaml-mcp-server/internal/pending"
 	"github.com/codex-k8s/yaml-mcp-server/internal/protocol"
 )
 
 type asyncResult struct {
-	status string
-	result string
+	Status string `json:"status"`
+	Result string `json:"result"`
 }
 
-type pendingExecution struct {
-	ch chan asyncResult
-}
-
-// PendingStore keeps async execution results.
-type PendingStore struct {
-	mu      sync.Mutex
-	pending map[string]*pendingExecution
-}
-
-// NewPendingStore creates a new async execution store.
-func NewPendingStore() *PendingStore {
-	return &PendingStore{pending: make(map[string]*pendingExecution)}
-}
-
-// Register allocates a pending slot for correlationID.
-func (s *PendingStore) Register(correlationID string) (<-chan asyncResult, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	if _, exists := s.pending[correlationID]; exists {
-		return nil, errExecutionAlreadyPending
-	}
-	ch := make(chan asyncResult, 1)
-	s.pending[correlationID] = &pendingExecution{ch: ch}
-	return ch, nil
-}
-
-// Resolve delivers an async execution result for correlationID.
-func (s *PendingStore) Resolve(correlationID, status, result string) bool {
-	entry, ok := maputil.Pop(&s.mu, s.pending, correlationID)
-	if !ok {
-		return false
-	}
+// PendingStore keeps async executions until a result webhook arrives.
+type PendingStore = pending.Store[asyncResult]
 
-	select {
-	case entry.ch <- asyncResult{status: status, result: result}:
-	default:
-	}
-	close(entry.ch)
-	return true
-}
-
-// Cancel removes a pending execution without a result.
-func (s *PendingStore) Cancel(correlationID string) {
-	entry, ok := maputil.Pop(&s.mu, s.pending, correlationID)
-	if ok {
-		close(entry.ch)
-	}
+// NewPendingStore creates an async execution store on top of backend (in-memory when nil),
+// keeping sent requests for ttl (pending.DefaultTTL when not positive).
+func NewPendingStore(backend pending.Backend, ttl time.Duration) *PendingStore {
+	return pending.NewStore[asyncResult](backend, ttl)
 }
 
 // WebhookHandler handles async executor callbacks.
@@ -84,9 +44,13 @@ func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
 	var payload protocol.ExecutorDecision
-	decoder := json.NewDecoder(r.Body)
-	if err := decoder.Decode(&payload); err != nil {
+	if err := json.Unmarshal(body, &payload); err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
@@ -98,12 +62,29 @@ func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	switch status {
-	case protocol.StatusSuccess, protocol.StatusError:
+	case protocol.StatusSuccess, protocol.StatusError, protocol.StatusProgress:
 	default:
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
+	if err := h.Store.Authorize(r, body, correlationID, payload.Token); err != nil {
+		if h.Logger != nil {
+			h.Logger.Warn("executor webhook rejected", "correlation_id", correlationID, "error", err)
+		}
+		if errors.Is(err, pending.ErrNotPending) {
+			w.WriteHeader(http.StatusNotFound)
+		} else {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+		return
+	}
+
+	if status == protocol.StatusProgress {
+		h.progress(w, correlationID, payload)
+		return
+	}
+
 	result := stringifyResult(payload.Result)
 	if status == protocol.StatusSuccess && strings.TrimSpace(result) == "" {
 		result = "ok"
@@ -112,7 +93,15 @@ func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		result = "executor error"
 	}
 
-	if !h.Store.Resolve(correlationID, status, result) {
+	resolved, err := h.Store.Resolve(correlationID, asyncResult{Status: status, Result: result})
+	if err != nil {
+		if h.Logger != nil {
+			h.Logger.Error("executor webhook failed", "correlation_id", correlationID, "error", err)
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if !resolved {
 		if h.Logger != nil {
 			h.Logger.Warn("executor webhook not found", "correlation_id", correlationID)
 		}
@@ -122,4 +111,28 @@ func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
-var errExecutionAlreadyPending = errors.New("execution already pending")
+const maxWebhookBody = 1 << 20
+
+func (h *WebhookHandler) progress(w http.ResponseWriter, correlationID string, payload protocol.ExecutorDecision) {
+	message := strings.TrimSpace(payload.Message)
+	if message == "" {
+		message = stringifyResult(payload.Result)
+	}
+	if payload.Percent != nil && (*payload.Percent < 0 || *payload.Percent > 100) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	delivered, err := h.Store.Progress(correlationID, message, payload.Percent)
+	if err != nil {
+		if h.Logger != nil {
+			h.Logger.Error("executor webhook failed", "correlation_id", correlationID, "error", err)
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if !delivered {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -1,6 +1,10 @@
 package executor
 
-import "context"
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
 
 // Request contains tool execution inputs.
 type Request struct {
@@ -12,6 +16,18 @@ type Request struct {
 	CorrelationID string
 	// TimeoutMessage is an optional timeout message.
 	TimeoutMessage string
+	// Session is the MCP session that issued the call, if any.
+	Session *mcp.ServerSession
+	// Progress receives intermediate updates (optional).
+	Progress func(Progress)
+}
+
+// Progress is an intermediate update from a running execution.
+type Progress struct {
+	// Message describes the current step.
+	Message string
+	// Percent is the completion percentage (0-100), nil when unknown.
+	Percent *float64
 }
 
 // Executor executes a tool command.
@@ -19,3 +35,9 @@ type Executor interface {
 	// Execute runs the tool logic and returns a message.
 	Execute(ctx context.Context, req Request) (string, error)
 }
+
+// Previewer is implemented by executors that can describe their effect before approval.
+type Previewer interface {
+	// Preview returns a human-readable change preview (for example, a unified diff).
+	Preview(ctx context.Context, req Request) (string, error)
+}
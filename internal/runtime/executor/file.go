@@ -0,0 +1,344 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/constants"
+	"github.com/codex-k8s/yaml-mcp-server/internal/executil"
+	"github.com/codex-k8s/yaml-mcp-server/internal/textdiff"
+)
+
+const defaultFileMaxBytes int64 = 1 << 20
+
+// File performs filesystem operations confined to configured root directories.
+type File struct {
+	// Operation is one of read, write, append, list, stat, delete.
+	Operation string
+	// Path is the templated target path (absolute or relative to the first root).
+	Path string
+	// Content is the templated content for write and append operations.
+	Content string
+	// Roots lists directories the executor may access.
+	Roots []string
+	// MaxBytes limits file size for read, write and append (default 1 MiB).
+	MaxBytes int64
+	// ClientRoots additionally confines paths to roots advertised by the MCP client.
+	ClientRoots bool
+}
+
+type fileTarget struct {
+	root *os.Root
+	rel  string
+	path string
+}
+
+type fileStat struct {
+	Path    string    `json:"path"`
+	Name    string    `json:"name"`
+	Size    int64     `json:"size"`
+	Mode    string    `json:"mode"`
+	ModTime time.Time `json:"mod_time"`
+	IsDir   bool      `json:"is_dir"`
+}
+
+// Execute runs the configured file operation.
+func (f File) Execute(ctx context.Context, req Request) (string, error) {
+	target, err := f.open(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	defer target.root.Close()
+
+	switch strings.ToLower(strings.TrimSpace(f.Operation)) {
+	case constants.FileOpRead:
+		data, err := f.readFile(target)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	case constants.FileOpWrite:
+		content, err := f.renderContent(req)
+		if err != nil {
+			return "", err
+		}
+		if dir := filepath.Dir(target.rel); dir != "." {
+			if err := target.root.MkdirAll(dir, 0o755); err != nil {
+				return "", fmt.Errorf("create parent directories: %w", err)
+			}
+		}
+		if err := target.root.WriteFile(target.rel, []byte(content), 0o644); err != nil {
+			return "", fmt.Errorf("write file: %w", err)
+		}
+		return fmt.Sprintf("wrote %d bytes to %s", len(content), target.path), nil
+	case constants.FileOpAppend:
+		content, err := f.renderContent(req)
+		if err != nil {
+			return "", err
+		}
+		file, err := target.root.OpenFile(target.rel, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return "", fmt.Errorf("open file: %w", err)
+		}
+		defer file.Close()
+		info, err := file.Stat()
+		if err != nil {
+			return "", fmt.Errorf("stat: %w", err)
+		}
+		if info.Size()+int64(len(content)) > f.maxBytes() {
+			return "", fmt.Errorf("file would exceed max_bytes (%d)", f.maxBytes())
+		}
+		if _, err := file.WriteString(content); err != nil {
+			return "", fmt.Errorf("append file: %w", err)
+		}
+		return fmt.Sprintf("appended %d bytes to %s", len(content), target.path), nil
+	case constants.FileOpList:
+		dir, err := target.root.Open(target.rel)
+		if err != nil {
+			return "", fmt.Errorf("open directory: %w", err)
+		}
+		defer dir.Close()
+		entries, err := dir.ReadDir(-1)
+		if err != nil {
+			return "", fmt.Errorf("read directory: %w", err)
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+		names := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			name := entry.Name()
+			if entry.IsDir() {
+				name += "/"
+			}
+			names = append(names, name)
+		}
+		return strings.Join(names, "\n"), nil
+	case constants.FileOpStat:
+		info, err := target.root.Stat(target.rel)
+		if err != nil {
+			return "", fmt.Errorf("stat: %w", err)
+		}
+		data, err := json.Marshal(fileStat{
+			Path:    target.path,
+			Name:    info.Name(),
+			Size:    info.Size(),
+			Mode:    info.Mode().String(),
+			ModTime: info.ModTime().UTC(),
+			IsDir:   info.IsDir(),
+		})
+		if err != nil {
+			return "", fmt.Errorf("encode stat: %w", err)
+		}
+		return string(data), nil
+	case constants.FileOpDelete:
+		if err := target.root.Remove(target.rel); err != nil {
+			return "", fmt.Errorf("delete: %w", err)
+		}
+		return fmt.Sprintf("deleted %s", target.path), nil
+	default:
+		return "", fmt.Errorf("unsupported file operation: %s", f.Operation)
+	}
+}
+
+// Preview returns a unified diff for write and append operations.
+func (f File) Preview(ctx context.Context, req Request) (string, error) {
+	op := strings.ToLower(strings.TrimSpace(f.Operation))
+	if op != constants.FileOpWrite && op != constants.FileOpAppend {
+		return "", nil
+	}
+	target, err := f.open(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	defer target.root.Close()
+
+	content, err := f.renderContent(req)
+	if err != nil {
+		return "", err
+	}
+	current, err := f.readFile(target)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+	next := content
+	if op == constants.FileOpAppend {
+		next = string(current) + content
+	}
+	oldName := target.path
+	if current == nil {
+		oldName = "/dev/null"
+	}
+	return textdiff.Unified(oldName, target.path, string(current), next), nil
+}
+
+func (f File) open(ctx context.Context, req Request) (fileTarget, error) {
+	rendered, err := executil.RenderTemplate(f.Path, templateData(req))
+	if err != nil {
+		return fileTarget{}, err
+	}
+	path := strings.TrimSpace(rendered)
+	if path == "" {
+		return fileTarget{}, errors.New("file path is empty")
+	}
+	if len(f.Roots) == 0 {
+		return fileTarget{}, errors.New("file executor roots are not configured")
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(f.Roots[0], path)
+	}
+	path = filepath.Clean(path)
+
+	rootDir, rel, ok := "", "", false
+	for _, root := range f.Roots {
+		if rel, ok = within(filepath.Clean(root), path); ok {
+			rootDir = root
+			break
+		}
+	}
+	if !ok {
+		return fileTarget{}, fmt.Errorf("path %s is outside allowed roots", path)
+	}
+
+	if f.ClientRoots {
+		roots, err := clientRoots(ctx, req.Session)
+		if err != nil {
+			return fileTarget{}, err
+		}
+		allowed := false
+		for _, root := range roots {
+			if clientRel, ok := within(root, path); ok && resolvesWithin(root, clientRel) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fileTarget{}, fmt.Errorf("path %s is outside client roots", path)
+		}
+	}
+
+	root, err := os.OpenRoot(rootDir)
+	if err != nil {
+		return fileTarget{}, fmt.Errorf("open root: %w", err)
+	}
+	return fileTarget{root: root, rel: rel, path: path}, nil
+}
+
+func (f File) readFile(target fileTarget) ([]byte, error) {
+	file, err := target.root.Open(target.rel)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("%s is a directory", target.path)
+	}
+	limit := f.maxBytes()
+	if info.Size() > limit {
+		return nil, fmt.Errorf("file exceeds max_bytes (%d)", limit)
+	}
+	data, err := io.ReadAll(io.LimitReader(file, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("file exceeds max_bytes (%d)", limit)
+	}
+	return data, nil
+}
+
+func (f File) renderContent(req Request) (string, error) {
+	content, err := executil.RenderTemplate(f.Content, templateData(req))
+	if err != nil {
+		return "", err
+	}
+	if int64(len(content)) > f.maxBytes() {
+		return "", fmt.Errorf("content exceeds max_bytes (%d)", f.maxBytes())
+	}
+	return content, nil
+}
+
+func (f File) maxBytes() int64 {
+	if f.MaxBytes > 0 {
+		return f.MaxBytes
+	}
+	return defaultFileMaxBytes
+}
+
+func templateData(req Request) executil.TemplateData {
+	return executil.TemplateData{
+		Args:          req.Arguments,
+		ToolName:      req.ToolName,
+		CorrelationID: req.CorrelationID,
+	}
+}
+
+func within(root, path string) (string, bool) {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return "", false
+	}
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return rel, true
+}
+
+// resolvesWithin reports whether rel stays inside dir once symlinks are followed through os.Root;
+// paths that do not exist yet are checked through their nearest existing parent.
+func resolvesWithin(dir, rel string) bool {
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return false
+	}
+	defer root.Close()
+	for {
+		_, err := root.Stat(rel)
+		if err == nil {
+			return true
+		}
+		if !errors.Is(err, fs.ErrNotExist) || rel == "." {
+			return false
+		}
+		rel = filepath.Dir(rel)
+	}
+}
+
+func clientRoots(ctx context.Context, session *mcp.ServerSession) ([]string, error) {
+	if session == nil {
+		return nil, errors.New("client roots are not available without an mcp session")
+	}
+	result, err := session.ListRoots(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("list client roots: %w", err)
+	}
+	roots := make([]string, 0, len(result.Roots))
+	for _, root := range result.Roots {
+		if root == nil {
+			continue
+		}
+		parsed, err := url.Parse(root.URI)
+		if err != nil || parsed.Scheme != "file" || parsed.Path == "" {
+			continue
+		}
+		roots = append(roots, filepath.Clean(parsed.Path))
+	}
+	if len(roots) == 0 {
+		return nil, errors.New("client did not advertise any file roots")
+	}
+	return roots, nil
+}
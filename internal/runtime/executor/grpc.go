@@ -0,0 +1,153 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	yamlmcpv1 "github.com/codex-k8s/yaml-mcp-server/api/yamlmcp/v1"
+	"github.com/codex-k8s/yaml-mcp-server/internal/grpcclient"
+	"github.com/codex-k8s/yaml-mcp-server/internal/protocol"
+	"github.com/codex-k8s/yaml-mcp-server/internal/secrets"
+)
+
+// GRPC calls an external gRPC executor.
+type GRPC struct {
+	// Service is the generated executor service client.
+	Service yamlmcpv1.ExecutorServiceClient
+	// Headers are sent as gRPC metadata.
+	Headers map[string]string
+	// Timeout limits unary calls (streams are bounded by the tool deadline).
+	Timeout time.Duration
+	// Stream uses ExecuteStream instead of the unary Execute call.
+	Stream bool
+	// Spec contains declarative executor settings.
+	Spec map[string]any
+	// Tool describes the tool metadata sent to external executor.
+	Tool protocol.ExecutorTool
+	// Lang defines the preferred language for messages.
+	Lang string
+	// Markup selects message formatting (markdown/html).
+	Markup string
+	// SecretHeaders adds metadata resolved from secrets at call time.
+	SecretHeaders map[string]secrets.Ref
+	// Secrets resolves SecretHeaders references.
+	Secrets *secrets.Store
+}
+
+// Execute sends the request to the gRPC executor and parses the result.
+func (g GRPC) Execute(ctx context.Context, req Request) (string, error) {
+	if g.Service == nil {
+		return "", errors.New("grpc executor is not configured")
+	}
+	payload, err := g.request(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	secretHeaders, err := g.Secrets.Resolve(ctx, g.SecretHeaders)
+	if err != nil {
+		return "", err
+	}
+	ctx = grpcclient.WithHeaders(ctx, g.Headers)
+	ctx = grpcclient.WithHeaders(ctx, secretHeaders)
+	var resp *yamlmcpv1.ExecutorResponse
+	if g.Stream {
+		resp, err = g.awaitStream(ctx, payload)
+	} else {
+		callCtx := ctx
+		if g.Timeout > 0 {
+			var cancel context.CancelFunc
+			callCtx, cancel = context.WithTimeout(ctx, g.Timeout)
+			defer cancel()
+		}
+		resp, err = g.Service.Execute(callCtx, payload)
+	}
+	if err != nil {
+		return "", fmt.Errorf("executor request failed: %w", err)
+	}
+
+	result := stringifyResult(resp.GetResult().AsInterface())
+	switch status := strings.ToLower(strings.TrimSpace(resp.GetStatus())); status {
+	case protocol.StatusSuccess:
+		if result == "" {
+			return "ok", nil
+		}
+		return result, nil
+	case protocol.StatusError:
+		if result == "" {
+			result = "executor error"
+		}
+		return result, errors.New(result)
+	default:
+		return "", fmt.Errorf("unknown executor status: %s", status)
+	}
+}
+
+func (g GRPC) request(ctx context.Context, req Request) (*yamlmcpv1.ExecutorRequest, error) {
+	arguments, err := grpcclient.ToStruct(req.Arguments)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := grpcclient.ToStruct(g.Spec)
+	if err != nil {
+		return nil, err
+	}
+	inputSchema, err := grpcclient.ToStruct(g.Tool.InputSchema)
+	if err != nil {
+		return nil, err
+	}
+	outputSchema, err := grpcclient.ToStruct(g.Tool.OutputSchema)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := grpcclient.ToStruct(g.Tool.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	payload := &yamlmcpv1.ExecutorRequest{
+		CorrelationId: req.CorrelationID,
+		Tool: &yamlmcpv1.ExecutorTool{
+			Name:         g.Tool.Name,
+			Title:        g.Tool.Title,
+			Description:  g.Tool.Description,
+			InputSchema:  inputSchema,
+			OutputSchema: outputSchema,
+			Metadata:     metadata,
+			Tags:         g.Tool.Tags,
+		},
+		Arguments: arguments,
+		Spec:      spec,
+		Lang:      normalizeLang(g.Lang, "en"),
+		Markup:    g.Markup,
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		payload.TimeoutSec = int32(max(1, int(time.Until(deadline).Seconds())))
+	}
+	return payload, nil
+}
+
+func (g GRPC) awaitStream(ctx context.Context, payload *yamlmcpv1.ExecutorRequest) (*yamlmcpv1.ExecutorResponse, error) {
+	stream, err := g.Service.ExecuteStream(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("executor stream closed without a final result")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if event.GetType() == yamlmcpv1.EventType_EVENT_TYPE_FINAL {
+			if event.GetResponse() == nil {
+				return nil, errors.New("executor final event has no response")
+			}
+			return event.GetResponse(), nil
+		}
+	}
+}
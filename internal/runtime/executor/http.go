@@ -11,7 +11,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/codex-k8s/yaml-mcp-server/internal/breaker"
+	"github.com/codex-k8s/yaml-mcp-server/internal/httpclient"
+	"github.com/codex-k8s/yaml-mcp-server/internal/pending"
 	"github.com/codex-k8s/yaml-mcp-server/internal/protocol"
+	"github.com/codex-k8s/yaml-mcp-server/internal/retry"
+	"github.com/codex-k8s/yaml-mcp-server/internal/runtime/approver"
+	"github.com/codex-k8s/yaml-mcp-server/internal/secrets"
+	"github.com/codex-k8s/yaml-mcp-server/internal/webhookauth"
 )
 
 // HTTP calls an external HTTP executor.
@@ -38,6 +45,20 @@ type HTTP struct {
 	Lang string
 	// Markup selects message formatting (markdown/html).
 	Markup string
+	// Endpoints adds failover URLs and circuit breakers (plain URL when nil).
+	Endpoints *breaker.Endpoints
+	// Transport applies an http_clients profile (default transport when nil).
+	Transport http.RoundTripper
+	// WebhookAuth verifies signed result callbacks (token-only check when nil).
+	WebhookAuth *webhookauth.Verifier
+	// SecretHeaders adds headers resolved from secrets at call time.
+	SecretHeaders map[string]secrets.Ref
+	// Secrets resolves SecretHeaders references.
+	Secrets *secrets.Store
+	// CancelURL is notified when the call ends by cancellation or timeout after the request was sent.
+	CancelURL string
+	// OnCancel is called after each cancellation notice (optional).
+	OnCancel func(ctx context.Context, correlationID string, err error)
 }
 
 // Execute sends execution request to external HTTP executor and parses result.
@@ -74,8 +95,10 @@ func (h HTTP) Execute(ctx context.Context, req Request) (string, error) {
 		Markup:        h.Markup,
 		TimeoutSec:    timeoutSec,
 	}
+	var callbackToken string
 	if h.Async {
-		payload.Callback = &protocol.ExecutorCallback{URL: h.WebhookURL}
+		callbackToken = webhookauth.NewToken()
+		payload.Callback = &protocol.ExecutorCallback{URL: h.WebhookURL, Token: callbackToken}
 	}
 
 	body, err := json.Marshal(payload)
@@ -87,48 +110,106 @@ func (h HTTP) Execute(ctx context.Context, req Request) (string, error) {
 	if method == "" {
 		method = http.MethodPost
 	}
-	request, err := http.NewRequestWithContext(ctx, method, h.URL, bytes.NewReader(body))
+	secretHeaders, err := h.Secrets.Resolve(ctx, h.SecretHeaders)
 	if err != nil {
-		return "", fmt.Errorf("failed to build request: %w", err)
-	}
-	request.Header.Set("Content-Type", "application/json")
-	for key, value := range h.Headers {
-		request.Header.Set(key, value)
+		return "", err
 	}
 
 	clientTimeout := h.Timeout
 	if clientTimeout <= 0 {
 		clientTimeout = 10 * time.Second
 	}
-	client := &http.Client{Timeout: clientTimeout}
+	client := &http.Client{Timeout: clientTimeout, Transport: h.Transport}
 
-	var pendingCh <-chan asyncResult
+	var (
+		pendingCh <-chan asyncResult
+		sent      bool
+	)
+	defer func() {
+		if sent && ctx.Err() != nil {
+			h.cancel(ctx, req.CorrelationID, secretHeaders)
+		}
+	}()
 	if h.Async {
-		ch, err := h.Pending.Register(req.CorrelationID)
+		argsHash, err := pending.ArgumentsHash(approver.ToolArgs(req.Arguments))
+		if err != nil {
+			return "", fmt.Errorf("failed to encode request: %w", err)
+		}
+		ch, state, err := h.Pending.Register(pending.Record{
+			CorrelationID: req.CorrelationID,
+			Source:        h.Tool.Name,
+			Tool:          req.ToolName,
+			ArgsHash:      argsHash,
+			Token:         callbackToken,
+			Deadline:      pending.Deadline(ctx, h.Timeout),
+		}, h.WebhookAuth)
 		if err != nil {
 			return "", err
 		}
 		pendingCh = ch
-		defer h.Pending.Cancel(req.CorrelationID)
+		if req.Progress != nil {
+			h.Pending.OnProgress(req.CorrelationID, func(message string, percent *float64) {
+				req.Progress(Progress{Message: message, Percent: percent})
+			})
+		}
+		defer func() {
+			// A sent request outlives a timed-out call (for the pending store TTL) unless it
+			// is cancelled remotely, so a retry can pick up a late result.
+			if sent && ctx.Err() != nil && strings.TrimSpace(h.CancelURL) == "" {
+				h.Pending.Cancel(req.CorrelationID)
+				return
+			}
+			_ = h.Pending.Complete(req.CorrelationID)
+		}()
+		if state != pending.StateNew {
+			sent = true
+			return h.awaitResult(ctx, pendingCh)
+		}
 	}
 
-	resp, err := client.Do(request)
-	if err != nil {
-		return "", fmt.Errorf("executor request failed: %w", err)
+	endpoints := h.Endpoints
+	if endpoints == nil {
+		endpoints = breaker.Direct(h.URL)
 	}
-	defer resp.Body.Close()
-
-	data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
-	dataTrimmed := strings.TrimSpace(string(data))
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		if h.Async && resp.StatusCode == http.StatusAccepted {
-			return h.awaitResult(ctx, pendingCh)
+	var (
+		statusCode int
+		data       []byte
+	)
+	err = endpoints.Do(ctx, func(ctx context.Context, url string) error {
+		request, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("failed to build request: %w", err)
+		}
+		request.Header.Set("Content-Type", "application/json")
+		for key, value := range h.Headers {
+			request.Header.Set(key, value)
+		}
+		for key, value := range secretHeaders {
+			request.Header.Set(key, value)
+		}
+		sent = true
+		resp, err := client.Do(request)
+		if err != nil {
+			return fmt.Errorf("executor request failed: %w", err)
+		}
+		defer resp.Body.Close()
+		statusCode = resp.StatusCode
+		data, _ = io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return &retry.StatusError{
+				Code:       resp.StatusCode,
+				RetryAfter: retry.ParseRetryAfter(resp.Header.Get("Retry-After")),
+				Message:    fmt.Sprintf("executor status %d: %s", resp.StatusCode, strings.TrimSpace(string(data))),
+			}
 		}
-		return "", fmt.Errorf("executor status %d: %s", resp.StatusCode, dataTrimmed)
+		return nil
+	})
+	if err != nil {
+		return "", err
 	}
+	dataTrimmed := strings.TrimSpace(string(data))
 
-	if h.Async && resp.StatusCode == http.StatusAccepted && len(bytes.TrimSpace(data)) == 0 {
+	if h.Async && statusCode == http.StatusAccepted && len(bytes.TrimSpace(data)) == 0 {
 		return h.awaitResult(ctx, pendingCh)
 	}
 
@@ -157,12 +238,23 @@ func (h HTTP) Execute(ctx context.Context, req Request) (string, error) {
 		}
 	}
 
-	if h.Async && resp.StatusCode == http.StatusAccepted {
+	if h.Async && statusCode == http.StatusAccepted {
 		return h.awaitResult(ctx, pendingCh)
 	}
 	return dataTrimmed, nil
 }
 
+// cancel notifies CancelURL that the call was cancelled or timed out.
+func (h HTTP) cancel(ctx context.Context, correlationID string, secretHeaders map[string]string) {
+	if strings.TrimSpace(h.CancelURL) == "" {
+		return
+	}
+	err := httpclient.Cancel(ctx, h.Transport, h.CancelURL, correlationID, h.Tool.Name, h.Headers, secretHeaders)
+	if h.OnCancel != nil {
+		h.OnCancel(ctx, correlationID, err)
+	}
+}
+
 func (h HTTP) awaitResult(ctx context.Context, pendingCh <-chan asyncResult) (string, error) {
 	if pendingCh == nil {
 		return "", errors.New("missing pending execution channel")
@@ -172,13 +264,13 @@ func (h HTTP) awaitResult(ctx context.Context, pendingCh <-chan asyncResult) (st
 		if !ok {
 			return "", errors.New("execution webhook channel closed")
 		}
-		if result.status == protocol.StatusSuccess {
-			return result.result, nil
+		if result.Status == protocol.StatusSuccess {
+			return result.Result, nil
 		}
-		if strings.TrimSpace(result.result) == "" {
+		if strings.TrimSpace(result.Result) == "" {
 			return "", errors.New("executor error")
 		}
-		return result.result, errors.New(result.result)
+		return result.Result, errors.New(result.Result)
 	case <-ctx.Done():
 		return "execution timeout", ctx.Err()
 	}
@@ -0,0 +1,64 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/plugin"
+	"github.com/codex-k8s/yaml-mcp-server/internal/protocol"
+)
+
+// Plugin executes tools through a long-lived subprocess plugin.
+type Plugin struct {
+	// Process is the plugin process handling requests.
+	Process *plugin.Process
+	// Spec contains declarative executor settings.
+	Spec map[string]any
+	// Tool describes the tool metadata sent to the plugin.
+	Tool protocol.ExecutorTool
+	// Lang defines the preferred language for messages.
+	Lang string
+	// Markup selects message formatting (markdown/html).
+	Markup string
+}
+
+// Execute calls the plugin "execute" method and parses the result.
+func (p Plugin) Execute(ctx context.Context, req Request) (string, error) {
+	if p.Process == nil {
+		return "", errors.New("plugin is not configured")
+	}
+	payload := protocol.ExecutorRequest{
+		CorrelationID: req.CorrelationID,
+		Tool:          p.Tool,
+		Arguments:     req.Arguments,
+		Spec:          p.Spec,
+		Lang:          normalizeLang(p.Lang, "en"),
+		Markup:        p.Markup,
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		payload.TimeoutSec = max(1, int(time.Until(deadline).Seconds()))
+	}
+
+	var resp protocol.ExecutorResponse
+	if err := p.Process.Call(ctx, plugin.MethodExecute, payload, &resp); err != nil {
+		return "", err
+	}
+	result := stringifyResult(resp.Result)
+	switch status := strings.ToLower(strings.TrimSpace(resp.Status)); status {
+	case protocol.StatusSuccess:
+		if result == "" {
+			return "ok", nil
+		}
+		return result, nil
+	case protocol.StatusError:
+		if result == "" {
+			result = "executor error"
+		}
+		return result, errors.New(result)
+	default:
+		return "", fmt.Errorf("unknown executor status: %s", status)
+	}
+}
@@ -0,0 +1,41 @@
+package executor
+
+import (
+	"context"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/retry"
+)
+
+// Retry re-runs a failed execution according to the policy.
+type Retry struct {
+	// Inner is the wrapped executor.
+	Inner Executor
+	// Policy controls attempts and backoff.
+	Policy *retry.Policy
+	// OnRetry is called before each retry (optional).
+	OnRetry func(ctx context.Context, req Request, attempt retry.Attempt)
+}
+
+// Execute runs the inner executor and retries transient failures.
+func (r Retry) Execute(ctx context.Context, req Request) (string, error) {
+	var output string
+	err := r.Policy.Do(ctx, func(ctx context.Context) error {
+		var err error
+		output, err = r.Inner.Execute(ctx, req)
+		return err
+	}, func(attempt retry.Attempt) {
+		if r.OnRetry != nil {
+			r.OnRetry(ctx, req, attempt)
+		}
+	})
+	return output, err
+}
+
+// Preview delegates to the inner executor when it supports previews.
+func (r Retry) Preview(ctx context.Context, req Request) (string, error) {
+	previewer, ok := r.Inner.(Previewer)
+	if !ok {
+		return "", nil
+	}
+	return previewer.Preview(ctx, req)
+}
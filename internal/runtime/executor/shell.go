@@ -2,9 +2,11 @@ package executor
 
 import (
 	"context"
+	"strconv"
 	"strings"
 
 	"github.com/codex-k8s/yaml-mcp-server/internal/executil"
+	"github.com/codex-k8s/yaml-mcp-server/internal/secrets"
 )
 
 // Shell executes a command as a tool.
@@ -15,17 +17,48 @@ type Shell struct {
 	Args []string
 	// Env adds environment variables.
 	Env map[string]string
+	// SecretEnv adds environment variables resolved from secrets at call time.
+	SecretEnv map[string]secrets.Ref
+	// Secrets resolves SecretEnv references.
+	Secrets *secrets.Store
+	// ProgressPrefix marks output lines reported as progress instead of output (disabled when empty).
+	ProgressPrefix string
 }
 
 // Execute runs the configured shell command.
 func (s Shell) Execute(ctx context.Context, req Request) (string, error) {
-	output, _, err := executil.RunCommand(ctx, s.Command, s.Args, s.Env, executil.TemplateData{
-		Args:          req.Arguments,
-		ToolName:      req.ToolName,
-		CorrelationID: req.CorrelationID,
-	})
+	secretEnv, err := s.Secrets.Resolve(ctx, s.SecretEnv)
+	if err != nil {
+		return "", err
+	}
+	var onLine func(string) bool
+	if s.ProgressPrefix != "" {
+		onLine = func(line string) bool {
+			rest, ok := strings.CutPrefix(strings.TrimSpace(line), s.ProgressPrefix)
+			if !ok {
+				return false
+			}
+			if req.Progress != nil {
+				req.Progress(parseProgress(rest))
+			}
+			return true
+		}
+	}
+	output, _, err := executil.RunCommandLines(ctx, s.Command, s.Args, s.Env, secretEnv, templateData(req), onLine)
 	if err != nil {
 		return strings.TrimSpace(output), err
 	}
 	return strings.TrimSpace(output), nil
 }
+
+// parseProgress reads "<percent>% <message>", where the percent is optional.
+func parseProgress(text string) Progress {
+	text = strings.TrimSpace(text)
+	head, tail, _ := strings.Cut(text, " ")
+	if number, ok := strings.CutSuffix(head, "%"); ok {
+		if percent, err := strconv.ParseFloat(number, 64); err == nil && percent >= 0 && percent <= 100 {
+			return Progress{Message: strings.TrimSpace(tail), Percent: &percent}
+		}
+	}
+	return Progress{Message: text}
+}
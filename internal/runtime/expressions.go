@@ -0,0 +1,96 @@
+package runtime
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/approver/policy"
+	"github.com/codex-k8s/yaml-mcp-server/internal/condition"
+	"github.com/codex-k8s/yaml-mcp-server/internal/dsl"
+	"github.com/codex-k8s/yaml-mcp-server/internal/runtime/approver"
+)
+
+// conditional wraps item so it only runs when the when expression holds.
+func (b Builder) conditional(tool dsl.ToolConfig, item approver.Approver, when string) (approver.Approver, error) {
+	program, err := condition.Compile(when)
+	if err != nil {
+		return nil, err
+	}
+	toolVars := toolVars(tool)
+	return approver.Conditional{
+		Inner: item,
+		When: func(req approver.Request) (bool, error) {
+			return program.Eval(condition.Vars{
+				Args: req.Arguments,
+				Tool: toolVars,
+				Caller: map[string]any{
+					"name":       req.Caller.Name,
+					"version":    req.Caller.Version,
+					"session_id": req.Caller.SessionID,
+				},
+			})
+		},
+		OnSkip: func(ctx context.Context, req approver.Request, name string) {
+			b.recordAudit(ctx, "approver_skipped", req.ToolName, req.CorrelationID, "", name+": "+program.String())
+		},
+		OnError: func(ctx context.Context, req approver.Request, name string, err error) {
+			if b.Logger != nil {
+				b.Logger.Warn("approver condition failed", "tool", req.ToolName, "correlation_id", req.CorrelationID, "approver", name, "error", err)
+			}
+			b.recordAudit(ctx, "approver_condition_error", req.ToolName, req.CorrelationID, "", name+": "+err.Error())
+		},
+	}, nil
+}
+
+// callerInfo describes the MCP client behind session.
+func callerInfo(session *mcp.ServerSession) approver.Caller {
+	if session == nil {
+		return approver.Caller{}
+	}
+	caller := approver.Caller{SessionID: session.ID()}
+	if params := session.InitializeParams(); params != nil && params.ClientInfo != nil {
+		caller.Name = params.ClientInfo.Name
+		caller.Version = params.ClientInfo.Version
+	}
+	return caller
+}
+
+// toolVars exposes tool name, title, tags, metadata and annotations to expressions.
+func toolVars(tool dsl.ToolConfig) map[string]any {
+	tags := make([]any, 0, len(tool.Tags))
+	for _, tag := range tool.Tags {
+		tags = append(tags, tag)
+	}
+	metadata := tool.Metadata
+	if metadata == nil {
+		metadata = map[string]any{}
+	}
+	annotations := map[string]any{}
+	if cfg := tool.Annotations; cfg != nil {
+		annotations["read_only_hint"] = cfg.ReadOnlyHint
+		annotations["idempotent_hint"] = cfg.IdempotentHint
+		if cfg.DestructiveHint != nil {
+			annotations["destructive_hint"] = *cfg.DestructiveHint
+		}
+		if cfg.OpenWorldHint != nil {
+			annotations["open_world_hint"] = *cfg.OpenWorldHint
+		}
+	}
+	return map[string]any{
+		"name":        tool.Name,
+		"title":       tool.Title,
+		"tags":        tags,
+		"metadata":    metadata,
+		"annotations": annotations,
+	}
+}
+
+// NewPolicyApprover builds the policy approver declared by cfg for tool.
+func NewPolicyApprover(tool dsl.ToolConfig, cfg dsl.ApproverConfig) (*policy.Approver, error) {
+	specs := make([]policy.RuleSpec, 0, len(cfg.Rules))
+	for _, rule := range cfg.Rules {
+		specs = append(specs, policy.RuleSpec{Name: rule.Name, When: rule.When, Effect: rule.Effect, Reason: rule.Reason})
+	}
+	return policy.New(cfg.Name, specs, cfg.Default, toolVars(tool))
+}
@@ -0,0 +1,30 @@
+package runtime
+
+import (
+	"context"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/dsl"
+	"github.com/codex-k8s/yaml-mcp-server/internal/protocol"
+	"github.com/codex-k8s/yaml-mcp-server/internal/runtime/approver"
+	"github.com/codex-k8s/yaml-mcp-server/internal/templates"
+)
+
+// fallbackRecorder audits that a client without capability was routed to a fallback approver.
+func (b Builder) fallbackRecorder(capability string) func(ctx context.Context, req approver.Request, name string) {
+	return func(ctx context.Context, req approver.Request, name string) {
+		client := "mcp client"
+		if req.Caller.Name != "" {
+			client += " " + req.Caller.Name
+		}
+		reason := client + " does not support " + capability + ", asking " + name
+		b.recordAudit(ctx, "approver_fallback", req.ToolName, req.CorrelationID, protocol.DecisionPending, reason)
+	}
+}
+
+// buildFallback builds the fallback approver of cfg (nil when not configured).
+func buildFallback(tool dsl.ToolConfig, path string, cfg dsl.ApproverConfig, renderer templates.Renderer, builder Builder) (approver.Approver, error) {
+	if cfg.Fallback == nil {
+		return nil, nil
+	}
+	return buildApprover(tool, path+".fallback", *cfg.Fallback, renderer, builder)
+}
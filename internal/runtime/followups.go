@@ -0,0 +1,77 @@
+package runtime
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/protocol"
+	"github.com/codex-k8s/yaml-mcp-server/internal/runtime/approver"
+)
+
+// followUpRetention is how long questions wait for the model to resubmit.
+const followUpRetention = 24 * time.Hour
+
+// followUps keeps needs_info questions by tool and correlation ID until the model resubmits.
+type followUps struct {
+	mu    sync.Mutex
+	items map[string]followUpEntry
+}
+
+type followUpEntry struct {
+	followUp  approver.FollowUp
+	expiresAt time.Time
+}
+
+func newFollowUps() *followUps {
+	return &followUps{items: make(map[string]followUpEntry)}
+}
+
+func (f *followUps) put(toolName, correlationID string, decision approver.Decision) {
+	if f == nil {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := time.Now()
+	for key, entry := range f.items {
+		if now.After(entry.expiresAt) {
+			delete(f.items, key)
+		}
+	}
+	f.items[toolName+":"+correlationID] = followUpEntry{
+		followUp:  approver.FollowUp{Source: decision.Source, Reason: decision.Reason, Questions: decision.Questions},
+		expiresAt: now.Add(followUpRetention),
+	}
+}
+
+// take returns and forgets the questions asked for correlationID.
+func (f *followUps) take(toolName, correlationID string) *approver.FollowUp {
+	if f == nil {
+		return nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := toolName + ":" + correlationID
+	entry, ok := f.items[key]
+	if !ok {
+		return nil
+	}
+	delete(f.items, key)
+	if time.Now().After(entry.expiresAt) {
+		return nil
+	}
+	return &entry.followUp
+}
+
+// applyNeedsInfo turns a needs_info decision into the response and remembers the questions for the resubmission.
+func (b Builder) applyNeedsInfo(ctx context.Context, resp *protocol.ToolResponse, toolName string, decision approver.Decision) {
+	resp.Status = protocol.StatusNeedsInfo
+	resp.Decision = protocol.DecisionNeedsInfo
+	resp.Questions = decision.Questions
+	resp.Reason = decision.Reason + "; answer the questions and call the tool again with correlation_id " + resp.CorrelationID
+	b.followUps.put(toolName, resp.CorrelationID, decision)
+	b.recordAudit(ctx, "approval_needs_info", toolName, resp.CorrelationID, protocol.DecisionNeedsInfo,
+		decision.Source+": "+strings.Join(decision.Questions, " | "))
+}
@@ -0,0 +1,118 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/constants"
+	"github.com/codex-k8s/yaml-mcp-server/internal/dsl"
+	"github.com/codex-k8s/yaml-mcp-server/internal/grants"
+	"github.com/codex-k8s/yaml-mcp-server/internal/protocol"
+	"github.com/codex-k8s/yaml-mcp-server/internal/runtime/approver"
+	"github.com/codex-k8s/yaml-mcp-server/internal/timeutil"
+)
+
+// grantChain keeps the approvers that still run while a grant is valid: everything except
+// the human-facing http, grpc, plugin and elicitation approvers and groups. The grant stands in
+// for them, so the chain approves even when nothing else applies.
+func grantChain(tool dsl.ToolConfig, chain approver.Chain) approver.Chain {
+	out := approver.Chain{Validate: chain.Validate, OnPatch: chain.OnPatch, OnMetadata: chain.OnMetadata}
+	for i, cfg := range tool.Approvers {
+		switch strings.ToLower(strings.TrimSpace(cfg.Type)) {
+		case constants.ApproverHTTP, constants.ApproverGRPC, constants.ApproverPlugin, constants.ApproverElicitation,
+			constants.ApproverAllOf, constants.ApproverAnyOf, constants.ApproverNOfM:
+			continue
+		}
+		out.Approvers = append(out.Approvers, chain.Approvers[i])
+	}
+	return out
+}
+
+// grantKey binds a grant to the tool and a hash of the selected argument fields.
+func grantKey(tool dsl.ToolConfig, args map[string]any) (string, error) {
+	selected := make(map[string]any)
+	if fields := tool.ApprovalGrant.Fields; len(fields) > 0 {
+		for _, field := range fields {
+			selected[field] = lookupArg(args, field)
+		}
+	} else {
+		// Meta-arguments are left out so reworded requests still match.
+		selected = approver.ToolArgs(args)
+	}
+	hash, err := hashArguments(selected)
+	if err != nil {
+		return "", err
+	}
+	return tool.Name + ":" + hash, nil
+}
+
+// lookupArg resolves a dot path such as "target.namespace" (nil when missing).
+func lookupArg(args map[string]any, path string) any {
+	var value any = args
+	for _, part := range strings.Split(path, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = object[part]
+	}
+	return value
+}
+
+// findGrant looks up a valid grant for the call without consuming it; the key is returned
+// either way, to use the grant or store a new one once the call is approved.
+func (b Builder) findGrant(tool dsl.ToolConfig, correlationID string, args map[string]any) (string, grants.Grant, bool) {
+	if tool.ApprovalGrant == nil || b.Grants == nil {
+		return "", grants.Grant{}, false
+	}
+	key, err := grantKey(tool, args)
+	if err != nil {
+		if b.Logger != nil {
+			b.Logger.Warn("approval grant key failed", "tool", tool.Name, "correlation_id", correlationID, "error", err)
+		}
+		return "", grants.Grant{}, false
+	}
+	grant, ok := b.Grants.Get(key)
+	return key, grant, ok
+}
+
+// useGrant consumes a use of the grant once the call is allowed. It reports false when the grant
+// expired or was used up by another call in the meantime.
+func (b Builder) useGrant(ctx context.Context, tool dsl.ToolConfig, key, correlationID string) bool {
+	grant, ok := b.Grants.Use(key)
+	if !ok {
+		return false
+	}
+	reason := fmt.Sprintf("granted by %s in %s", grant.Approver, grant.CorrelationID)
+	if grant.Remaining >= 0 {
+		reason += fmt.Sprintf(" (%d uses left)", grant.Remaining)
+	}
+	b.recordAudit(ctx, "approval_grant_used", tool.Name, correlationID, protocol.DecisionApprove, reason)
+	return true
+}
+
+// storeGrant records an approval and its argument patch for later identical calls.
+// Nothing is stored when every approver was skipped, as nobody approved.
+func (b Builder) storeGrant(ctx context.Context, tool dsl.ToolConfig, key, correlationID string, decision approver.Decision) {
+	if key == "" || decision.Source == "" {
+		return
+	}
+	ttl := timeutil.ParseDurationOrDefault(tool.ApprovalGrant.TTL, 0)
+	grant := b.Grants.Put(key, decision.Source, correlationID, decision.Patch, ttl, tool.ApprovalGrant.MaxUses)
+	b.recordAudit(ctx, "approval_grant_stored", tool.Name, correlationID, protocol.DecisionApprove,
+		fmt.Sprintf("granted by %s until %s", grant.Approver, grant.ExpiresAt.UTC().Format(time.RFC3339)))
+}
+
+// mergeGrantPatch combines the patch stored with a grant and the patch of the approvers that still ran.
+func mergeGrantPatch(grantPatch, patch map[string]any) map[string]any {
+	if len(grantPatch) == 0 {
+		return patch
+	}
+	merged := make(map[string]any, len(grantPatch)+len(patch))
+	maps.Copy(merged, grantPatch)
+	maps.Copy(merged, patch)
+	return merged
+}
@@ -0,0 +1,102 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/dsl"
+)
+
+func TestGrantKey(t *testing.T) {
+	base := map[string]any{
+		"namespace":     "prod",
+		"deployment":    "api",
+		"target":        map[string]any{"cluster": "eu-1"},
+		"justification": "restart after config change",
+	}
+	tests := []struct {
+		name   string
+		tool   string
+		fields []string
+		first  map[string]any
+		second map[string]any
+		same   bool
+	}{
+		{
+			name:   "identical call",
+			first:  base,
+			second: base,
+			same:   true,
+		},
+		{
+			name:  "meta arguments are ignored",
+			first: base,
+			second: map[string]any{
+				"namespace":        "prod",
+				"deployment":       "api",
+				"target":           map[string]any{"cluster": "eu-1"},
+				"justification":    "retry",
+				"approval_request": "restart api",
+				"risk_assessment":  "low",
+				"links_to_code":    []any{map[string]any{"url": "https://example.com"}},
+				"response_format":  "json",
+				"correlation_id":   "corr-2",
+				"request_id":       "req-2",
+			},
+			same: true,
+		},
+		{
+			name:   "other argument value",
+			first:  base,
+			second: map[string]any{"namespace": "staging", "deployment": "api", "target": map[string]any{"cluster": "eu-1"}},
+		},
+		{
+			name:   "extra argument",
+			first:  base,
+			second: map[string]any{"namespace": "prod", "deployment": "api", "target": map[string]any{"cluster": "eu-1"}, "force": true},
+		},
+		{
+			name:   "unselected fields are ignored",
+			fields: []string{"namespace", "target.cluster"},
+			first:  base,
+			second: map[string]any{"namespace": "prod", "deployment": "worker", "target": map[string]any{"cluster": "eu-1"}},
+			same:   true,
+		},
+		{
+			name:   "nested field differs",
+			fields: []string{"namespace", "target.cluster"},
+			first:  base,
+			second: map[string]any{"namespace": "prod", "deployment": "api", "target": map[string]any{"cluster": "us-1"}},
+		},
+		{
+			name:   "missing field differs from present field",
+			fields: []string{"namespace", "target.cluster"},
+			first:  base,
+			second: map[string]any{"namespace": "prod"},
+		},
+		{
+			name:   "other tool",
+			tool:   "k8s_delete",
+			first:  base,
+			second: base,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tool := dsl.ToolConfig{Name: "k8s_restart", ApprovalGrant: &dsl.ApprovalGrantConfig{Fields: tt.fields}}
+			first, err := grantKey(tool, tt.first)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.tool != "" {
+				tool.Name = tt.tool
+			}
+			second, err := grantKey(tool, tt.second)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (first == second) != tt.same {
+				t.Fatalf("grantKey() = %q and %q, want same = %v", first, second, tt.same)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/dsl"
+	"github.com/codex-k8s/yaml-mcp-server/internal/httpclient"
+	"github.com/codex-k8s/yaml-mcp-server/internal/secrets"
+	"github.com/codex-k8s/yaml-mcp-server/internal/timeutil"
+	"github.com/codex-k8s/yaml-mcp-server/internal/tlsutil"
+	"github.com/codex-k8s/yaml-mcp-server/internal/webhookauth"
+)
+
+func buildHTTPClients(cfg map[string]dsl.HTTPClientConfig, store *secrets.Store) (map[string]http.RoundTripper, error) {
+	if len(cfg) == 0 {
+		return nil, nil
+	}
+	out := make(map[string]http.RoundTripper, len(cfg))
+	for name, item := range cfg {
+		clientCfg := httpclient.Config{
+			BearerTokenFile: item.BearerTokenFile,
+			Proxy:           item.Proxy,
+		}
+		if item.TLS != nil {
+			clientCfg.TLS = &tlsutil.Config{
+				CAFile:             item.TLS.CAFile,
+				CertFile:           item.TLS.CertFile,
+				KeyFile:            item.TLS.KeyFile,
+				ServerName:         item.TLS.ServerName,
+				InsecureSkipVerify: item.TLS.InsecureSkipVerify,
+			}
+		}
+		if item.HMAC != nil {
+			clientCfg.HMACKeyFile = item.HMAC.KeyFile
+			clientCfg.SignatureHeader = item.HMAC.SignatureHeader
+			clientCfg.TimestampHeader = item.HMAC.TimestampHeader
+			if ref := strings.TrimSpace(item.HMAC.SecretRef); ref != "" {
+				if store == nil {
+					return nil, fmt.Errorf("http client %s: secrets are not configured", name)
+				}
+				clientCfg.HMACKey = func(ctx context.Context) (string, error) {
+					return store.Get(ctx, ref)
+				}
+			}
+		}
+		transport, err := httpclient.NewTransport(clientCfg)
+		if err != nil {
+			return nil, fmt.Errorf("http client %s: %w", name, err)
+		}
+		out[name] = transport
+	}
+	return out, nil
+}
+
+func webhookVerifier(name string, cfg *dsl.WebhookAuthConfig, store *secrets.Store) *webhookauth.Verifier {
+	if cfg == nil {
+		return nil
+	}
+	key := func(ctx context.Context) (string, error) {
+		return secrets.File{Path: cfg.KeyFile}.Fetch(ctx)
+	}
+	if ref := strings.TrimSpace(cfg.SecretRef); ref != "" {
+		key = func(ctx context.Context) (string, error) {
+			if store == nil {
+				return "", fmt.Errorf("secret %s is not configured", ref)
+			}
+			return store.Get(ctx, ref)
+		}
+	}
+	return webhookauth.NewVerifier(webhookauth.Config{
+		Name:            name,
+		Key:             key,
+		SignatureHeader: cfg.SignatureHeader,
+		TimestampHeader: cfg.TimestampHeader,
+		NonceHeader:     cfg.NonceHeader,
+		MaxAge:          timeutil.ParseDurationOrDefault(cfg.MaxAge, webhookauth.DefaultMaxAge),
+	})
+}
@@ -0,0 +1,99 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/constants"
+	"github.com/codex-k8s/yaml-mcp-server/internal/dsl"
+	"github.com/codex-k8s/yaml-mcp-server/internal/operations"
+	"github.com/codex-k8s/yaml-mcp-server/internal/protocol"
+)
+
+type operationStatusInput struct {
+	CorrelationID string `json:"correlation_id" jsonschema:"correlation_id returned by the deferred tool call"`
+}
+
+func isDeferred(tool dsl.ToolConfig) bool {
+	return strings.EqualFold(strings.TrimSpace(tool.AsyncResponse), constants.AsyncResponseDeferred)
+}
+
+// startOperation runs a deferred tool call in the background and returns the pending response.
+func (b Builder) startOperation(ctx context.Context, session *mcp.ServerSession, tool dsl.ToolConfig, correlationID string, run func(context.Context) protocol.ToolResponse) protocol.ToolResponse {
+	resp := protocol.ToolResponse{
+		Status:        protocol.StatusPending,
+		Decision:      protocol.DecisionPending,
+		Reason:        fmt.Sprintf("operation started; call %s with this correlation_id for the result", constants.OperationStatusTool),
+		CorrelationID: correlationID,
+	}
+	if err := b.Operations.Start(tool.Name, correlationID, callerInfo(session).SessionID); err != nil {
+		if errors.Is(err, operations.ErrOtherSession) {
+			return protocol.ToolResponse{
+				Status:        protocol.StatusError,
+				Decision:      protocol.DecisionError,
+				Reason:        "correlation_id is already used by another operation",
+				CorrelationID: correlationID,
+			}
+		}
+		resp.Reason = fmt.Sprintf("operation is already running; call %s with this correlation_id for the result", constants.OperationStatusTool)
+		return resp
+	}
+	b.recordAudit(ctx, "operation_started", tool.Name, correlationID, protocol.DecisionPending, "")
+
+	bg := context.WithoutCancel(ctx)
+	go func() {
+		final := run(bg)
+		final.Reason = b.Secrets.Redact(final.Reason)
+		b.Operations.Finish(correlationID, final)
+		b.recordAudit(bg, "operation_finished", tool.Name, correlationID, final.Decision, final.Reason)
+		if !tool.AsyncNotify || session == nil {
+			return
+		}
+		err := session.Log(bg, &mcp.LoggingMessageParams{
+			Level:  "info",
+			Logger: "yaml-mcp-server",
+			Data: map[string]any{
+				"event":    "operation_completed",
+				"tool":     tool.Name,
+				"response": final,
+			},
+		})
+		if err != nil && b.Logger != nil {
+			b.Logger.Warn("operation notification failed", "tool", tool.Name, "correlation_id", correlationID, "error", err)
+		}
+	}()
+	return resp
+}
+
+func (b Builder) addOperationStatusTool(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        constants.OperationStatusTool,
+		Description: "Returns the status or final result of a deferred tool call by correlation_id.",
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true, IdempotentHint: true},
+	}, func(_ context.Context, req *mcp.CallToolRequest, input operationStatusInput) (*mcp.CallToolResult, protocol.ToolResponse, error) {
+		correlationID := strings.TrimSpace(input.CorrelationID)
+		op, ok := b.Operations.Get(correlationID, callerInfo(req.Session).SessionID)
+		switch {
+		case !ok:
+			return nil, protocol.ToolResponse{
+				Status:        protocol.StatusError,
+				Decision:      protocol.DecisionError,
+				Reason:        "unknown operation",
+				CorrelationID: correlationID,
+			}, nil
+		case !op.Done:
+			return nil, protocol.ToolResponse{
+				Status:        protocol.StatusPending,
+				Decision:      protocol.DecisionPending,
+				Reason:        fmt.Sprintf("operation %s is still running", op.Tool),
+				CorrelationID: correlationID,
+			}, nil
+		default:
+			return nil, op.Response, nil
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/dsl"
+	"github.com/codex-k8s/yaml-mcp-server/internal/protocol"
+	"github.com/codex-k8s/yaml-mcp-server/internal/runtime/approver"
+)
+
+// argumentsValidator validates patched arguments against the tool input_schema.
+func argumentsValidator(tool dsl.ToolConfig) (func(args map[string]any) error, error) {
+	data, err := json.Marshal(tool.InputSchema)
+	if err != nil {
+		return nil, fmt.Errorf("input_schema: %w", err)
+	}
+	var schema jsonschema.Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("input_schema: %w", err)
+	}
+	resolved, err := schema.Resolve(nil)
+	if err != nil {
+		return nil, fmt.Errorf("input_schema: %w", err)
+	}
+	return func(args map[string]any) error {
+		// Round-trip through JSON so values have the types the schema validator expects.
+		data, err := json.Marshal(args)
+		if err != nil {
+			return err
+		}
+		var instance map[string]any
+		if err := json.Unmarshal(data, &instance); err != nil {
+			return err
+		}
+		return resolved.Validate(instance)
+	}, nil
+}
+
+func (b Builder) recordPatch(ctx context.Context, req approver.Request, decision approver.Decision) {
+	b.recordAudit(ctx, "arguments_patched", req.ToolName, req.CorrelationID, protocol.DecisionApprove, decision.Source+": "+b.argumentsJSON(decision.Patch))
+}
+
+func (b Builder) recordMetadata(ctx context.Context, req approver.Request, decision approver.Decision) {
+	verdict := protocol.DecisionApprove
+	if !decision.Allowed {
+		verdict = protocol.DecisionDeny
+	}
+	b.recordAudit(ctx, "approver_metadata", req.ToolName, req.CorrelationID, verdict, decision.Source+": "+b.argumentsJSON(decision.Metadata))
+}
+
+// argumentsJSON renders arguments for audit with secret values redacted.
+func (b Builder) argumentsJSON(args map[string]any) string {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return fmt.Sprint(args)
+	}
+	return b.Secrets.Redact(string(data))
+}
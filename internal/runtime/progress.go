@@ -0,0 +1,42 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/runtime/executor"
+)
+
+// progressReporter audits executor progress and forwards it as MCP progress notifications when token is set.
+func (b Builder) progressReporter(ctx context.Context, session *mcp.ServerSession, token any, toolName, correlationID string) func(executor.Progress) {
+	var (
+		mu    sync.Mutex
+		count float64
+	)
+	return func(update executor.Progress) {
+		message := b.Secrets.Redact(update.Message)
+		reason := message
+		if update.Percent != nil {
+			reason = strings.TrimSpace(fmt.Sprintf("%g%% %s", *update.Percent, message))
+		}
+		b.recordAudit(ctx, "executor_progress", toolName, correlationID, "", reason)
+		if session == nil || token == nil {
+			return
+		}
+		mu.Lock()
+		count++
+		params := &mcp.ProgressNotificationParams{ProgressToken: token, Message: message, Progress: count}
+		if update.Percent != nil {
+			params.Progress = *update.Percent
+			params.Total = 100
+		}
+		mu.Unlock()
+		if err := session.NotifyProgress(ctx, params); err != nil && b.Logger != nil {
+			b.Logger.Warn("progress notification failed", "tool", toolName, "correlation_id", correlationID, "error", err)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/constants"
+	"github.com/codex-k8s/yaml-mcp-server/internal/dsl"
+	"github.com/codex-k8s/yaml-mcp-server/internal/protocol"
+	"github.com/codex-k8s/yaml-mcp-server/internal/runtime/approver"
+	"github.com/codex-k8s/yaml-mcp-server/internal/templates"
+	"github.com/codex-k8s/yaml-mcp-server/internal/timeutil"
+)
+
+// buildQuorum builds an all_of, any_of or n_of_m group from its children.
+func buildQuorum(tool dsl.ToolConfig, path string, cfg dsl.ApproverConfig, renderer templates.Renderer, builder Builder) (approver.Approver, error) {
+	children := make([]approver.Approver, 0, len(cfg.Approvers))
+	for i, childCfg := range cfg.Approvers {
+		child, err := buildApprover(tool, fmt.Sprintf("%s.approvers[%d]", path, i), childCfg, renderer, builder)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+	}
+	required := cfg.Quorum
+	switch strings.ToLower(strings.TrimSpace(cfg.Type)) {
+	case constants.ApproverAllOf:
+		required = 0
+	case constants.ApproverAnyOf:
+		required = 1
+	}
+	group := approver.Quorum{
+		Label:      cfg.Name,
+		Required:   required,
+		Approvers:  children,
+		AllowEmpty: strings.TrimSpace(cfg.When) != "",
+		OnDecision: builder.recordApproverDecision,
+	}
+	return wrapTimeout(group, timeutil.ParseDurationOrDefault(cfg.Timeout, 0)), nil
+}
+
+func (b Builder) recordApproverDecision(ctx context.Context, req approver.Request, decision approver.Decision) {
+	verdict := protocol.DecisionDeny
+	switch {
+	case decision.Allowed:
+		verdict = protocol.DecisionApprove
+	case decision.NeedsInfo:
+		verdict = protocol.DecisionNeedsInfo
+	}
+	b.recordAudit(ctx, "approver_decision", req.ToolName, req.CorrelationID, verdict, decision.Source+": "+decision.Reason)
+}
@@ -0,0 +1,131 @@
+package runtime
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/dsl"
+	"github.com/codex-k8s/yaml-mcp-server/internal/httpclient"
+	"github.com/codex-k8s/yaml-mcp-server/internal/protocol"
+	"github.com/codex-k8s/yaml-mcp-server/internal/runtime/approver"
+	"github.com/codex-k8s/yaml-mcp-server/internal/secrets"
+)
+
+// resultSummaryLimit caps the result summary sent to approvers (in runes).
+const resultSummaryLimit = 1000
+
+// resultCallback is an HTTP approver that wants the execution result.
+type resultCallback struct {
+	target string
+	// correlationID is the id the approver received (derived for group children and escalation levels).
+	correlationID string
+	url           string
+	headers       map[string]string
+	secretHeaders map[string]secrets.Ref
+	transport     http.RoundTripper
+}
+
+// approvedCallbacks collects the result callbacks of the approvers that approved one call.
+type approvedCallbacks struct {
+	mu    sync.Mutex
+	items []resultCallback
+}
+
+type approvedCallbacksKey struct{}
+
+// withApprovedCallbacks attaches an empty collector for the call to ctx.
+func withApprovedCallbacks(ctx context.Context) (context.Context, *approvedCallbacks) {
+	approved := &approvedCallbacks{}
+	return context.WithValue(ctx, approvedCallbacksKey{}, approved), approved
+}
+
+func (c *approvedCallbacks) add(callback resultCallback) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items = append(c.items, callback)
+}
+
+func (c *approvedCallbacks) list() []resultCallback {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.items)
+}
+
+// resultNotifier registers the result callback of an HTTP approver for the call once it approves,
+// so only approvers that actually granted approval are notified.
+type resultNotifier struct {
+	approver.Approver
+	callback resultCallback
+}
+
+// withResultCallback wraps an HTTP approver that has a result_callback_url.
+func (b Builder) withResultCallback(item approver.Approver, target string, cfg dsl.ApproverConfig) approver.Approver {
+	url := strings.TrimSpace(cfg.ResultCallbackURL)
+	if url == "" {
+		return item
+	}
+	return resultNotifier{Approver: item, callback: resultCallback{
+		target:        target,
+		url:           url,
+		headers:       cfg.Headers,
+		secretHeaders: secretRefs(cfg.SecretHeaders),
+		transport:     b.httpClients[strings.TrimSpace(cfg.HTTPClient)],
+	}}
+}
+
+// Approve runs the approver and registers its result callback when it approves.
+func (n resultNotifier) Approve(ctx context.Context, req approver.Request) (approver.Decision, error) {
+	decision, err := n.Approver.Approve(ctx, req)
+	if err == nil && decision.Allowed {
+		if approved, ok := ctx.Value(approvedCallbacksKey{}).(*approvedCallbacks); ok {
+			callback := n.callback
+			callback.correlationID = req.CorrelationID
+			approved.add(callback)
+		}
+	}
+	return decision, err
+}
+
+// sendResults posts the execution result to the callbacks of the approvers that approved the call in the background.
+func (b Builder) sendResults(ctx context.Context, callbacks []resultCallback, toolName, correlationID, status, summary string, duration time.Duration) {
+	if len(callbacks) == 0 {
+		return
+	}
+	summary = truncateRunes(strings.TrimSpace(b.Secrets.Redact(summary)), resultSummaryLimit)
+	ctx = context.WithoutCancel(ctx)
+	for _, callback := range callbacks {
+		payload := protocol.ApproverResult{
+			CorrelationID: callback.correlationID,
+			Tool:          toolName,
+			Status:        status,
+			Summary:       summary,
+			DurationMs:    duration.Milliseconds(),
+		}
+		go func() {
+			secretHeaders, err := b.Secrets.Resolve(ctx, callback.secretHeaders)
+			if err == nil {
+				err = httpclient.PostJSON(ctx, callback.transport, callback.url, payload, callback.headers, secretHeaders)
+			}
+			reason := callback.target + ": " + status
+			if err != nil {
+				reason = callback.target + ": " + b.Secrets.Redact(err.Error())
+				if b.Logger != nil {
+					b.Logger.Warn("result callback failed", "tool", toolName, "correlation_id", correlationID, "target", callback.target, "error", reason)
+				}
+			}
+			b.recordAudit(ctx, "result_callback", toolName, correlationID, "", reason)
+		}()
+	}
+}
+
+func truncateRunes(value string, limit int) string {
+	runes := []rune(value)
+	if len(runes) <= limit {
+		return value
+	}
+	return string(runes[:limit]) + "…"
+}
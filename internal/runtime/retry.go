@@ -0,0 +1,55 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/dsl"
+	"github.com/codex-k8s/yaml-mcp-server/internal/retry"
+	"github.com/codex-k8s/yaml-mcp-server/internal/runtime/approver"
+	"github.com/codex-k8s/yaml-mcp-server/internal/runtime/executor"
+	"github.com/codex-k8s/yaml-mcp-server/internal/timeutil"
+)
+
+func retryPolicy(cfg *dsl.RetryConfig) *retry.Policy {
+	if cfg == nil {
+		return nil
+	}
+	policy := &retry.Policy{
+		MaxAttempts:    cfg.MaxAttempts,
+		InitialBackoff: timeutil.ParseDurationOrDefault(cfg.InitialBackoff, 500*time.Millisecond),
+		MaxBackoff:     timeutil.ParseDurationOrDefault(cfg.MaxBackoff, 10*time.Second),
+		Multiplier:     cfg.Multiplier,
+		Jitter:         0.2,
+		StatusCodes:    cfg.RetryOnStatus,
+		ExitCodes:      cfg.RetryOnExitCodes,
+	}
+	if policy.MaxAttempts == 0 {
+		policy.MaxAttempts = 3
+	}
+	if policy.Multiplier == 0 {
+		policy.Multiplier = 2
+	}
+	if cfg.Jitter != nil {
+		policy.Jitter = *cfg.Jitter
+	}
+	return policy
+}
+
+func (b Builder) recordExecutorRetry(ctx context.Context, req executor.Request, attempt retry.Attempt) {
+	b.recordRetry(ctx, "executor_retry", req.ToolName, req.CorrelationID, attempt)
+}
+
+func (b Builder) recordApproverRetry(ctx context.Context, req approver.Request, attempt retry.Attempt) {
+	b.recordRetry(ctx, "approver_retry", req.ToolName, req.CorrelationID, attempt)
+}
+
+func (b Builder) recordRetry(ctx context.Context, eventType, tool, correlationID string, attempt retry.Attempt) {
+	reason := fmt.Sprintf("attempt %d failed: %s; retrying in %s", attempt.Number, attempt.Err, attempt.Delay.Round(time.Millisecond))
+	if b.Logger != nil {
+		b.Logger.Warn("retrying call", "type", eventType, "tool", tool, "correlation_id", correlationID,
+			"attempt", attempt.Number, "delay", attempt.Delay, "error", b.Secrets.Redact(attempt.Err.Error()))
+	}
+	b.recordAudit(ctx, eventType, tool, correlationID, "", reason)
+}
@@ -0,0 +1,76 @@
+package runtime
+
+import (
+	"crypto/tls"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/constants"
+	"github.com/codex-k8s/yaml-mcp-server/internal/dsl"
+	"github.com/codex-k8s/yaml-mcp-server/internal/secrets"
+	"github.com/codex-k8s/yaml-mcp-server/internal/timeutil"
+	"github.com/codex-k8s/yaml-mcp-server/internal/tlsutil"
+)
+
+const defaultSecretTTL = 5 * time.Minute
+
+func buildSecrets(cfg map[string]dsl.SecretConfig) (*secrets.Store, error) {
+	store := secrets.NewStore()
+	names := make([]string, 0, len(cfg))
+	for name := range cfg {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		item := cfg[name]
+		var provider secrets.Provider
+		switch strings.ToLower(strings.TrimSpace(item.Type)) {
+		case constants.SecretFile:
+			provider = secrets.File{Path: item.Path}
+		case constants.SecretEnv:
+			provider = secrets.Env{Name: item.Name}
+		case constants.SecretKubernetes:
+			provider = secrets.Kubernetes{Namespace: item.Namespace, Name: item.Name, Key: item.Key}
+		case constants.SecretVault:
+			var tlsCfg *tls.Config
+			if item.TLS != nil {
+				built, err := tlsutil.Config{
+					CAFile:             item.TLS.CAFile,
+					CertFile:           item.TLS.CertFile,
+					KeyFile:            item.TLS.KeyFile,
+					ServerName:         item.TLS.ServerName,
+					InsecureSkipVerify: item.TLS.InsecureSkipVerify,
+				}.Build()
+				if err != nil {
+					return nil, fmt.Errorf("secret %s: %w", name, err)
+				}
+				tlsCfg = built
+			}
+			provider = secrets.Vault{
+				Address:   item.Address,
+				Path:      item.Path,
+				Field:     item.Key,
+				TokenFile: item.TokenFile,
+				TokenEnv:  item.TokenEnv,
+				TLS:       tlsCfg,
+			}
+		default:
+			return nil, fmt.Errorf("secret %s: unknown secret type: %s", name, item.Type)
+		}
+		store.Add(name, provider, timeutil.ParseDurationOrDefault(item.TTL, defaultSecretTTL))
+	}
+	return store, nil
+}
+
+func secretRefs(refs map[string]dsl.SecretRef) map[string]secrets.Ref {
+	if len(refs) == 0 {
+		return nil
+	}
+	out := make(map[string]secrets.Ref, len(refs))
+	for key, ref := range refs {
+		out[key] = secrets.Ref{Name: strings.TrimSpace(ref.SecretRef), Prefix: ref.Prefix}
+	}
+	return out
+}
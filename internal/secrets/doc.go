@@ -0,0 +1,2 @@
+// Package secrets resolves named secrets at call time, caches them and redacts them from output.
+package secrets
@@ -0,0 +1,216 @@
+package secrets
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+	requestTimeout    = 10 * time.Second
+	maxResponseBytes  = 1 << 20
+)
+
+// File reads a secret from a file (e.g. a mounted Kubernetes secret).
+type File struct {
+	// Path is the file path.
+	Path string
+}
+
+// Fetch reads the file and strips the trailing newline.
+func (f File) Fetch(_ context.Context) (string, error) {
+	data, err := os.ReadFile(f.Path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// Env reads a secret from a server environment variable.
+type Env struct {
+	// Name is the variable name.
+	Name string
+}
+
+// Fetch returns the variable value.
+func (e Env) Fetch(_ context.Context) (string, error) {
+	value, ok := os.LookupEnv(e.Name)
+	if !ok {
+		return "", fmt.Errorf("env %s is not set", e.Name)
+	}
+	return value, nil
+}
+
+// Kubernetes reads a key from a Secret via the in-cluster API.
+type Kubernetes struct {
+	// Namespace is the Secret namespace (the pod namespace when empty).
+	Namespace string
+	// Name is the Secret name.
+	Name string
+	// Key is the data key inside the Secret.
+	Key string
+}
+
+// Fetch calls the Kubernetes API with the pod service account token.
+func (k Kubernetes) Fetch(ctx context.Context) (string, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return "", errors.New("kubernetes provider requires in-cluster environment")
+	}
+	token, err := os.ReadFile(serviceAccountDir + "/token")
+	if err != nil {
+		return "", fmt.Errorf("read service account token: %w", err)
+	}
+	namespace := strings.TrimSpace(k.Namespace)
+	if namespace == "" {
+		data, err := os.ReadFile(serviceAccountDir + "/namespace")
+		if err != nil {
+			return "", fmt.Errorf("read pod namespace: %w", err)
+		}
+		namespace = strings.TrimSpace(string(data))
+	}
+	caData, err := os.ReadFile(serviceAccountDir + "/ca.crt")
+	if err != nil {
+		return "", fmt.Errorf("read cluster ca: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caData) {
+		return "", errors.New("cluster ca contains no certificates")
+	}
+
+	endpoint := fmt.Sprintf("https://%s/api/v1/namespaces/%s/secrets/%s",
+		net.JoinHostPort(host, port), url.PathEscape(namespace), url.PathEscape(k.Name))
+	client := &http.Client{
+		Timeout:   requestTimeout,
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: pool}},
+	}
+	var secret struct {
+		Data map[string]string `json:"data"`
+	}
+	if err := getJSON(ctx, client, endpoint, map[string]string{
+		"Authorization": "Bearer " + strings.TrimSpace(string(token)),
+	}, &secret); err != nil {
+		return "", err
+	}
+	encoded, ok := secret.Data[k.Key]
+	if !ok {
+		return "", fmt.Errorf("key %s not found in secret %s/%s", k.Key, namespace, k.Name)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("decode secret data: %w", err)
+	}
+	return string(decoded), nil
+}
+
+// Vault reads a field from a HashiCorp Vault KV secret (v1 or v2).
+type Vault struct {
+	// Address is the Vault base URL.
+	Address string
+	// Path is the API path after /v1/ (e.g. secret/data/db for KV v2).
+	Path string
+	// Field is the field inside the secret data.
+	Field string
+	// TokenFile holds the Vault token (re-read on every fetch).
+	TokenFile string
+	// TokenEnv names the env variable with the token (VAULT_TOKEN when both are empty).
+	TokenEnv string
+	// TLS configures the client (system roots when nil).
+	TLS *tls.Config
+}
+
+// Fetch reads the secret via the Vault HTTP API.
+func (v Vault) Fetch(ctx context.Context) (string, error) {
+	token, err := v.token()
+	if err != nil {
+		return "", err
+	}
+	endpoint := strings.TrimRight(v.Address, "/") + "/v1/" + strings.TrimLeft(v.Path, "/")
+	client := &http.Client{Timeout: requestTimeout}
+	if v.TLS != nil {
+		client.Transport = &http.Transport{TLSClientConfig: v.TLS}
+	}
+	var secret struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := getJSON(ctx, client, endpoint, map[string]string{"X-Vault-Token": token}, &secret); err != nil {
+		return "", err
+	}
+	data := secret.Data
+	// KV v2 nests the payload under data.data.
+	if nested, ok := data["data"].(map[string]any); ok {
+		if _, direct := data[v.Field]; !direct {
+			data = nested
+		}
+	}
+	value, ok := data[v.Field]
+	if !ok {
+		return "", fmt.Errorf("field %s not found at %s", v.Field, v.Path)
+	}
+	switch typed := value.(type) {
+	case string:
+		return typed, nil
+	default:
+		raw, err := json.Marshal(typed)
+		if err != nil {
+			return "", err
+		}
+		return string(raw), nil
+	}
+}
+
+func (v Vault) token() (string, error) {
+	if path := strings.TrimSpace(v.TokenFile); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("read vault token: %w", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+	name := strings.TrimSpace(v.TokenEnv)
+	if name == "" {
+		name = "VAULT_TOKEN"
+	}
+	token := strings.TrimSpace(os.Getenv(name))
+	if token == "" {
+		return "", fmt.Errorf("vault token env %s is empty", name)
+	}
+	return token, nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, endpoint string, headers map[string]string, out any) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "application/json")
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+	resp, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		// The body is not included: error responses may echo request details.
+		return fmt.Errorf("secret request failed with status %d", resp.StatusCode)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("decode secret response: %w", err)
+	}
+	return nil
+}
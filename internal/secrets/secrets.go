@@ -0,0 +1,132 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Redacted replaces secret values in tool output and audit records.
+const Redacted = "[REDACTED]"
+
+// minRedactLength skips very short values that would mangle unrelated output.
+const minRedactLength = 4
+
+// Provider fetches the current value of a single secret.
+type Provider interface {
+	// Fetch returns the secret value.
+	Fetch(ctx context.Context) (string, error)
+}
+
+// Ref points to a named secret.
+type Ref struct {
+	// Name is the secret name from the secrets block.
+	Name string
+	// Prefix is prepended to the resolved value (e.g. "Bearer ").
+	Prefix string
+}
+
+type entry struct {
+	provider Provider
+	ttl      time.Duration
+}
+
+type cached struct {
+	value   string
+	expires time.Time
+}
+
+// Store resolves secrets lazily and remembers resolved values for redaction.
+type Store struct {
+	mu      sync.Mutex
+	entries map[string]entry
+	cache   map[string]cached
+	seen    map[string]struct{}
+}
+
+// NewStore returns an empty Store.
+func NewStore() *Store {
+	return &Store{
+		entries: map[string]entry{},
+		cache:   map[string]cached{},
+		seen:    map[string]struct{}{},
+	}
+}
+
+// Add registers a provider under name; ttl <= 0 disables caching.
+func (s *Store) Add(name string, provider Provider, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[name] = entry{provider: provider, ttl: ttl}
+}
+
+// Get returns the secret value, fetching it when the cached copy expired.
+func (s *Store) Get(ctx context.Context, name string) (string, error) {
+	if s == nil {
+		return "", fmt.Errorf("secret %q: secrets are not configured", name)
+	}
+	s.mu.Lock()
+	item, ok := s.entries[name]
+	if !ok {
+		s.mu.Unlock()
+		return "", fmt.Errorf("secret %q is not declared", name)
+	}
+	if hit, ok := s.cache[name]; ok && time.Now().Before(hit.expires) {
+		s.mu.Unlock()
+		return hit.value, nil
+	}
+	s.mu.Unlock()
+
+	value, err := item.provider.Fetch(ctx)
+	if err != nil {
+		return "", fmt.Errorf("secret %q: %w", name, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if item.ttl > 0 {
+		s.cache[name] = cached{value: value, expires: time.Now().Add(item.ttl)}
+	}
+	if len(value) >= minRedactLength {
+		s.seen[value] = struct{}{}
+	}
+	return value, nil
+}
+
+// Resolve returns key -> prefixed secret value for every reference.
+func (s *Store) Resolve(ctx context.Context, refs map[string]Ref) (map[string]string, error) {
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	out := make(map[string]string, len(refs))
+	for key, ref := range refs {
+		value, err := s.Get(ctx, ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		out[key] = ref.Prefix + value
+	}
+	return out, nil
+}
+
+// Redact replaces every secret value resolved so far with Redacted.
+func (s *Store) Redact(text string) string {
+	if s == nil || text == "" {
+		return text
+	}
+	s.mu.Lock()
+	values := make([]string, 0, len(s.seen))
+	for value := range s.seen {
+		values = append(values, value)
+	}
+	s.mu.Unlock()
+	// Longer values first so a secret containing another one is replaced whole.
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+	for _, value := range values {
+		text = strings.ReplaceAll(text, value, Redacted)
+	}
+	return text
+}
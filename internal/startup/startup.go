@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"os"
 	"strings"
 	"time"
 
@@ -31,7 +32,7 @@ func Run(ctx context.Context, hooks []dsl.HookConfig, logger *slog.Logger) error
 			logger.Info("running startup hook", "index", idx)
 		}
 
-		output, _, err := executil.RunCommand(hookCtx, hook.Command, hook.Args, hook.Env, executil.TemplateData{})
+		output, _, err := executil.RunCommand(hookCtx, hook.Command, hook.Args, hook.Env, serverEnv(hook.Env), executil.TemplateData{})
 		if err != nil {
 			if logger != nil && strings.TrimSpace(output) != "" {
 				logger.Error("startup hook failed", "index", idx, "output", strings.TrimSpace(output))
@@ -50,3 +51,16 @@ func Run(ctx context.Context, hooks []dsl.HookConfig, logger *slog.Logger) error
 	}
 	return nil
 }
+
+// serverEnv returns the server environment except keys the hook declares; unlike tool commands,
+// startup hooks are written by the operator and inherit the full environment.
+func serverEnv(declared map[string]string) map[string]string {
+	env := make(map[string]string)
+	for _, item := range os.Environ() {
+		key, value, ok := strings.Cut(item, "=")
+		if _, exists := declared[key]; ok && !exists {
+			env[key] = value
+		}
+	}
+	return env
+}
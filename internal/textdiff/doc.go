@@ -0,0 +1,2 @@
+// Package textdiff renders line-based unified diffs for approval previews.
+package textdiff
@@ -0,0 +1,165 @@
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxCells bounds the LCS table size; larger inputs produce a full replacement diff.
+const maxCells = 4_000_000
+
+// contextLines is the number of unchanged lines kept around each change.
+const contextLines = 3
+
+type opKind byte
+
+const (
+	opEqual  opKind = ' '
+	opDelete opKind = '-'
+	opInsert opKind = '+'
+)
+
+type op struct {
+	kind opKind
+	line string
+	// a and b are 0-based line indexes in old and new text.
+	a, b int
+}
+
+// Unified returns a unified diff between oldText and newText.
+// It returns an empty string when both texts are equal.
+func Unified(oldName, newName, oldText, newText string) string {
+	if oldText == newText {
+		return ""
+	}
+	a := splitLines(oldText)
+	b := splitLines(newText)
+	ops := diffLines(a, b)
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", oldName, newName)
+	for _, hunk := range hunks(ops) {
+		writeHunk(&out, hunk)
+	}
+	return out.String()
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func diffLines(a, b []string) []op {
+	if len(a)*len(b) > maxCells {
+		return replaceAll(a, b)
+	}
+	// lcs[i][j] holds the LCS length of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]op, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, op{kind: opEqual, line: a[i], a: i, b: j})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, op{kind: opDelete, line: a[i], a: i, b: j})
+			i++
+		default:
+			ops = append(ops, op{kind: opInsert, line: b[j], a: i, b: j})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, op{kind: opDelete, line: a[i], a: i, b: j})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, op{kind: opInsert, line: b[j], a: i, b: j})
+	}
+	return ops
+}
+
+func replaceAll(a, b []string) []op {
+	ops := make([]op, 0, len(a)+len(b))
+	for i, line := range a {
+		ops = append(ops, op{kind: opDelete, line: line, a: i})
+	}
+	for j, line := range b {
+		ops = append(ops, op{kind: opInsert, line: line, a: len(a), b: j})
+	}
+	return ops
+}
+
+func hunks(ops []op) [][]op {
+	var out [][]op
+	start := -1
+	lastChange := -1
+	for idx, item := range ops {
+		if item.kind == opEqual {
+			continue
+		}
+		if start >= 0 && idx-lastChange > 2*contextLines {
+			out = append(out, ops[start:min(len(ops), lastChange+contextLines+1)])
+			start = -1
+		}
+		if start < 0 {
+			start = max(0, idx-contextLines)
+		}
+		lastChange = idx
+	}
+	if start >= 0 {
+		out = append(out, ops[start:min(len(ops), lastChange+contextLines+1)])
+	}
+	return out
+}
+
+func writeHunk(out *strings.Builder, hunk []op) {
+	oldStart, newStart := hunk[0].a, hunk[0].b
+	oldCount, newCount := 0, 0
+	for _, item := range hunk {
+		if item.kind != opInsert {
+			oldCount++
+		}
+		if item.kind != opDelete {
+			newCount++
+		}
+	}
+	fmt.Fprintf(out, "@@ -%s +%s @@\n", hunkRange(oldStart, oldCount), hunkRange(newStart, newCount))
+	for _, item := range hunk {
+		out.WriteByte(byte(item.kind))
+		out.WriteString(item.line)
+		if !strings.HasSuffix(item.line, "\n") {
+			out.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
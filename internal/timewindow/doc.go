@@ -0,0 +1,2 @@
+// Package timewindow parses weekly time windows and date periods used by schedule approvers.
+package timewindow
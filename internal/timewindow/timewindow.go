@@ -0,0 +1,142 @@
+package timewindow
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// Window is a weekly time window; From and To are offsets since local midnight.
+type Window struct {
+	// Days lists the weekdays the window applies to (every day when empty).
+	Days []time.Weekday
+	// From is the window start.
+	From time.Duration
+	// To is the window end (exclusive).
+	To time.Duration
+}
+
+// Period is a closed interval [Start, End) such as a freeze or a holiday.
+type Period struct {
+	// Start is the first blocked instant.
+	Start time.Time
+	// End is the first instant after the period.
+	End time.Time
+	// Reason describes the period (freeze reason or holiday name).
+	Reason string
+}
+
+// PeriodSpec declares a period in config or freeze files.
+type PeriodSpec struct {
+	// From is a date (2006-01-02) or RFC3339 time.
+	From string `yaml:"from" json:"from"`
+	// To is a date (inclusive) or RFC3339 time (exclusive); defaults to the end of From's day.
+	To string `yaml:"to" json:"to"`
+	// Reason describes the freeze.
+	Reason string `yaml:"reason" json:"reason"`
+}
+
+// ParseWindow parses weekday names (mon..sun) and HH:MM bounds ("24:00" ends the day).
+func ParseWindow(days []string, from, to string) (Window, error) {
+	window := Window{}
+	for _, day := range days {
+		name := strings.ToLower(strings.TrimSpace(day))
+		if len(name) > 3 {
+			name = name[:3]
+		}
+		weekday, ok := weekdays[name]
+		if !ok {
+			return Window{}, fmt.Errorf("unknown weekday: %s", day)
+		}
+		window.Days = append(window.Days, weekday)
+	}
+	var err error
+	if window.From, err = parseClock(from); err != nil {
+		return Window{}, fmt.Errorf("from: %w", err)
+	}
+	if window.To, err = parseClock(to); err != nil {
+		return Window{}, fmt.Errorf("to: %w", err)
+	}
+	if window.From >= window.To {
+		return Window{}, fmt.Errorf("from must be before to")
+	}
+	return window, nil
+}
+
+// ParsePeriod parses a PeriodSpec; dates are interpreted in loc.
+func ParsePeriod(spec PeriodSpec, loc *time.Location) (Period, error) {
+	start, startIsDate, err := parseInstant(spec.From, loc)
+	if err != nil {
+		return Period{}, fmt.Errorf("from: %w", err)
+	}
+	end := start.AddDate(0, 0, 1)
+	if !startIsDate {
+		end = StartOfDay(start).AddDate(0, 0, 1)
+	}
+	if strings.TrimSpace(spec.To) != "" {
+		parsed, isDate, err := parseInstant(spec.To, loc)
+		if err != nil {
+			return Period{}, fmt.Errorf("to: %w", err)
+		}
+		end = parsed
+		if isDate {
+			end = parsed.AddDate(0, 0, 1)
+		}
+	}
+	if !end.After(start) {
+		return Period{}, fmt.Errorf("to must be after from")
+	}
+	return Period{Start: start, End: end, Reason: strings.TrimSpace(spec.Reason)}, nil
+}
+
+// Matches reports whether the window applies to day.
+func (w Window) Matches(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, item := range w.Days {
+		if item == day {
+			return true
+		}
+	}
+	return false
+}
+
+func parseClock(value string) (time.Duration, error) {
+	hours, minutes, ok := strings.Cut(strings.TrimSpace(value), ":")
+	if !ok {
+		return 0, fmt.Errorf("must be HH:MM: %q", value)
+	}
+	h, errH := strconv.Atoi(hours)
+	m, errM := strconv.Atoi(minutes)
+	if errH != nil || errM != nil || h < 0 || m < 0 || m > 59 || h > 24 || (h == 24 && m != 0) {
+		return 0, fmt.Errorf("must be HH:MM: %q", value)
+	}
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+}
+
+func parseInstant(value string, loc *time.Location) (time.Time, bool, error) {
+	value = strings.TrimSpace(value)
+	if parsed, err := time.ParseInLocation(dateLayout, value, loc); err == nil {
+		return parsed, true, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("must be a date (2006-01-02) or RFC3339 time: %q", value)
+	}
+	return parsed, false, nil
+}
+
+// StartOfDay returns local midnight of t's day.
+func StartOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,2 @@
+// Package tlsutil builds TLS client configurations from file-based settings.
+package tlsutil
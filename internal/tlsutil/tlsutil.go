@@ -0,0 +1,56 @@
+package tlsutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Config describes client-side TLS settings.
+type Config struct {
+	// CAFile is a PEM bundle with trusted CAs (system roots when empty).
+	CAFile string
+	// CertFile is a PEM client certificate for mTLS.
+	CertFile string
+	// KeyFile is a PEM client key for mTLS.
+	KeyFile string
+	// ServerName overrides the expected server name.
+	ServerName string
+	// InsecureSkipVerify disables server certificate verification.
+	InsecureSkipVerify bool
+}
+
+// Build loads certificates and returns a client TLS configuration.
+func (c Config) Build() (*tls.Config, error) {
+	out := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         strings.TrimSpace(c.ServerName),
+		InsecureSkipVerify: c.InsecureSkipVerify, //nolint:gosec // explicitly requested in config
+	}
+	if caFile := strings.TrimSpace(c.CAFile); caFile != "" {
+		data, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("read ca file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, errors.New("ca file contains no certificates")
+		}
+		out.RootCAs = pool
+	}
+	certFile, keyFile := strings.TrimSpace(c.CertFile), strings.TrimSpace(c.KeyFile)
+	if certFile != "" || keyFile != "" {
+		if certFile == "" || keyFile == "" {
+			return nil, errors.New("cert_file and key_file must be set together")
+		}
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate: %w", err)
+		}
+		out.Certificates = []tls.Certificate{cert}
+	}
+	return out, nil
+}
@@ -0,0 +1,2 @@
+// Package webhookauth verifies signed async webhook callbacks and issues single-use callback tokens.
+package webhookauth
@@ -0,0 +1,134 @@
+package webhookauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/codex-k8s/yaml-mcp-server/internal/httpclient"
+)
+
+// Defaults for callback verification.
+const (
+	DefaultSignatureHeader = httpclient.DefaultSignatureHeader
+	DefaultTimestampHeader = httpclient.DefaultTimestampHeader
+	DefaultNonceHeader     = "X-YAML-MCP-Nonce"
+	DefaultMaxAge          = 5 * time.Minute
+)
+
+// ErrUnauthorized is returned when a callback fails verification.
+var ErrUnauthorized = errors.New("webhook unauthorized")
+
+// Config describes callback verification settings.
+type Config struct {
+	// Name identifies the verifier in persisted pending requests.
+	Name string
+	// Key returns the shared signing key.
+	Key func(ctx context.Context) (string, error)
+	// SignatureHeader carries "sha256=<hex>" (DefaultSignatureHeader when empty).
+	SignatureHeader string
+	// TimestampHeader carries the unix timestamp (DefaultTimestampHeader when empty).
+	TimestampHeader string
+	// NonceHeader carries a unique value per callback (DefaultNonceHeader when empty).
+	NonceHeader string
+	// MaxAge bounds the accepted clock skew (DefaultMaxAge when zero).
+	MaxAge time.Duration
+}
+
+// Verifier checks callback signatures, timestamps and nonces.
+type Verifier struct {
+	cfg Config
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// NewVerifier creates a verifier with defaults applied.
+func NewVerifier(cfg Config) *Verifier {
+	if strings.TrimSpace(cfg.SignatureHeader) == "" {
+		cfg.SignatureHeader = DefaultSignatureHeader
+	}
+	if strings.TrimSpace(cfg.TimestampHeader) == "" {
+		cfg.TimestampHeader = DefaultTimestampHeader
+	}
+	if strings.TrimSpace(cfg.NonceHeader) == "" {
+		cfg.NonceHeader = DefaultNonceHeader
+	}
+	if cfg.MaxAge <= 0 {
+		cfg.MaxAge = DefaultMaxAge
+	}
+	return &Verifier{cfg: cfg, seen: make(map[string]time.Time)}
+}
+
+// Name returns the verifier name.
+func (v *Verifier) Name() string {
+	return v.cfg.Name
+}
+
+// Sign returns the signature a callback sender puts into the signature header.
+func Sign(key, timestamp, nonce string, body []byte) string {
+	return httpclient.Sign(key, timestamp+"."+nonce, body)
+}
+
+// Verify checks the signature over "<timestamp>.<nonce>.<body>", rejects stale timestamps and reused nonces.
+func (v *Verifier) Verify(r *http.Request, body []byte) error {
+	timestamp := strings.TrimSpace(r.Header.Get(v.cfg.TimestampHeader))
+	nonce := strings.TrimSpace(r.Header.Get(v.cfg.NonceHeader))
+	signature := strings.TrimSpace(r.Header.Get(v.cfg.SignatureHeader))
+	if timestamp == "" || nonce == "" || signature == "" {
+		return fmt.Errorf("%w: signature headers are missing", ErrUnauthorized)
+	}
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: timestamp is invalid", ErrUnauthorized)
+	}
+	now := time.Now()
+	if skew := now.Sub(time.Unix(unix, 0)); skew > v.cfg.MaxAge || skew < -v.cfg.MaxAge {
+		return fmt.Errorf("%w: timestamp is stale", ErrUnauthorized)
+	}
+	key, err := v.cfg.Key(r.Context())
+	if err != nil {
+		return fmt.Errorf("webhook key: %w", err)
+	}
+	if subtle.ConstantTimeCompare([]byte(signature), []byte(Sign(key, timestamp, nonce, body))) != 1 {
+		return fmt.Errorf("%w: signature mismatch", ErrUnauthorized)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for seenNonce, at := range v.seen {
+		if now.Sub(at) > 2*v.cfg.MaxAge {
+			delete(v.seen, seenNonce)
+		}
+	}
+	if _, ok := v.seen[nonce]; ok {
+		return fmt.Errorf("%w: nonce reused", ErrUnauthorized)
+	}
+	v.seen[nonce] = now
+	return nil
+}
+
+// NewToken returns a random single-use callback token.
+func NewToken() string {
+	buf := make([]byte, 24)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// CheckToken compares a callback token with the issued one in constant time.
+// A missing token is rejected, whether or not callbacks are also signed.
+func CheckToken(issued, got string) error {
+	got = strings.TrimSpace(got)
+	if issued == "" || got == "" || subtle.ConstantTimeCompare([]byte(issued), []byte(got)) != 1 {
+		return fmt.Errorf("%w: callback token mismatch", ErrUnauthorized)
+	}
+	return nil
+}
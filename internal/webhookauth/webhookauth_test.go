@@ -0,0 +1,89 @@
+package webhookauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const testKey = "secret"
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"correlation_id":"corr-1","decision":"approve"}`)
+	now := time.Now()
+	tests := []struct {
+		name      string
+		timestamp string
+		nonce     string
+		signature string
+		replay    bool
+		wantErr   bool
+	}{
+		{name: "valid", timestamp: unix(now), nonce: "n1", signature: Sign(testKey, unix(now), "n1", body)},
+		{name: "missing headers", wantErr: true},
+		{name: "bad signature", timestamp: unix(now), nonce: "n1", signature: Sign("other", unix(now), "n1", body), wantErr: true},
+		{name: "signature over other nonce", timestamp: unix(now), nonce: "n1", signature: Sign(testKey, unix(now), "n2", body), wantErr: true},
+		{name: "invalid timestamp", timestamp: "soon", nonce: "n1", signature: Sign(testKey, "soon", "n1", body), wantErr: true},
+		{name: "stale timestamp", timestamp: unix(now.Add(-time.Hour)), nonce: "n1", signature: Sign(testKey, unix(now.Add(-time.Hour)), "n1", body), wantErr: true},
+		{name: "future timestamp", timestamp: unix(now.Add(time.Hour)), nonce: "n1", signature: Sign(testKey, unix(now.Add(time.Hour)), "n1", body), wantErr: true},
+		{name: "reused nonce", timestamp: unix(now), nonce: "n1", signature: Sign(testKey, unix(now), "n1", body), replay: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := NewVerifier(Config{Name: "test", Key: func(context.Context) (string, error) { return testKey, nil }})
+			request := func() error {
+				r := httptest.NewRequest(http.MethodPost, "/webhook", nil)
+				r.Header.Set(DefaultTimestampHeader, tt.timestamp)
+				r.Header.Set(DefaultNonceHeader, tt.nonce)
+				r.Header.Set(DefaultSignatureHeader, tt.signature)
+				return verifier.Verify(r, body)
+			}
+			if tt.replay {
+				if err := request(); err != nil {
+					t.Fatalf("first Verify() error = %v", err)
+				}
+			}
+			err := request()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrUnauthorized) {
+				t.Fatalf("Verify() error = %v, want ErrUnauthorized", err)
+			}
+		})
+	}
+}
+
+func TestCheckToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		issued  string
+		got     string
+		wantErr bool
+	}{
+		{name: "matching token", issued: "abc", got: "abc"},
+		{name: "surrounding spaces", issued: "abc", got: " abc "},
+		{name: "missing token", issued: "abc", got: "", wantErr: true},
+		{name: "wrong token", issued: "abc", got: "abd", wantErr: true},
+		{name: "nothing issued", issued: "", got: "abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckToken(tt.issued, tt.got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrUnauthorized) {
+				t.Fatalf("CheckToken() error = %v, want ErrUnauthorized", err)
+			}
+		})
+	}
+}
+
+func unix(t time.Time) string {
+	return strconv.FormatInt(t.Unix(), 10)
+}